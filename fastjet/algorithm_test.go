@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fmom"
 	"gonum.org/v1/gonum/floats"
 )
 
@@ -364,6 +365,146 @@ func TestExclusiveJetAlgorithms(t *testing.T) {
 	}
 }
 
+func TestAntiKtSimple(t *testing.T) {
+	// massless particles at y=0, with (pt, phi):
+	//  - a hard particle at phi=0,
+	//  - a soft particle at dR=0.3 from it: d12 = 1/100^2 * 0.3^2/0.4^2 < d1B = 1/100^2,
+	//  - a soft particle at dR=1.5 from it: outside of the R=0.4 cone.
+	// anti-kt merges the close soft particle into the hard one and
+	// leaves the other one as a separate jet.
+	particles := []fastjet.Jet{
+		masslessJet(100, 0, 0),
+		masslessJet(1, 0, 0.3),
+		masslessJet(2, 0, 1.5),
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 0.4, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Sort(fastjet.ByPt(jets))
+
+	want := [][4]float64{
+		{
+			particles[0].Px() + particles[1].Px(),
+			particles[0].Py() + particles[1].Py(),
+			0,
+			particles[0].E() + particles[1].E(),
+		},
+		{particles[2].Px(), particles[2].Py(), 0, particles[2].E()},
+	}
+
+	if len(jets) != len(want) {
+		t.Fatalf("got %d jets, want %d", len(jets), len(want))
+	}
+	for i, jet := range jets {
+		got := []float64{jet.Px(), jet.Py(), jet.Pz(), jet.E()}
+		if !floats.EqualApprox(got, want[i][:], 1e-12) {
+			t.Fatalf("jet #%d:\ngot= %v\nwant=%v", i, got, want[i])
+		}
+	}
+}
+
+func TestExclusiveJetsN(t *testing.T) {
+	particles := []fastjet.Jet{
+		fastjet.NewJet(10, 0, 0, 10),
+		fastjet.NewJet(9, 1, 0, math.Sqrt(82)),
+		fastjet.NewJet(-10, 0, 1, math.Sqrt(101)),
+		fastjet.NewJet(-1, -8, 0, math.Sqrt(65)),
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.KtAlgorithm, 0.7, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for n := 1; n <= len(particles); n++ {
+		jets, err := cs.ExclusiveJetsN(n)
+		if err != nil {
+			t.Fatalf("n=%d: %+v", n, err)
+		}
+		if len(jets) != n {
+			t.Fatalf("n=%d: got %d jets", n, len(jets))
+		}
+	}
+
+	_, err = cs.ExclusiveJetsN(len(particles) + 1)
+	if err == nil {
+		t.Fatalf("expected an error when requesting more jets than particles")
+	}
+
+	jets, err := cs.ExclusiveJetsUpTo(len(particles) + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jets) != len(particles) {
+		t.Fatalf("got %d jets, want %d", len(jets), len(particles))
+	}
+
+	for _, dcut := range []float64{0, 1, 10, 100, 1000} {
+		jets, err := cs.ExclusiveJets(dcut)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(jets), cs.NumExclusiveJets(dcut); got != want {
+			t.Fatalf("dcut=%v: got %d jets, want %d", dcut, got, want)
+		}
+	}
+}
+
+func TestConstituentsNested(t *testing.T) {
+	// massless particles at y=0, with (pt, phi), clustered by C/A
+	// into a single jet through nested merges: ((p0+p1)+p2)+p3.
+	particles := []fastjet.Jet{
+		masslessJet(10, 0, 0),
+		masslessJet(5, 0, 0.1),
+		masslessJet(3, 0, 0.3),
+		masslessJet(1, 0, 0.6),
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.CambridgeAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jets) != 1 {
+		t.Fatalf("got %d jets, want 1", len(jets))
+	}
+
+	for _, cts := range [][]fastjet.Jet{
+		jets[0].Constituents(),
+		func() []fastjet.Jet {
+			cts, err := cs.Constituents(&jets[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return cts
+		}(),
+	} {
+		if len(cts) != len(particles) {
+			t.Fatalf("got %d constituents, want %d", len(cts), len(particles))
+		}
+		sort.Sort(fastjet.ByPt(cts))
+		for i := range cts {
+			if !fmom.Equal(&cts[i], &particles[i]) {
+				t.Fatalf("constituent #%d:\ngot= %v\nwant=%v", i, cts[i].PxPyPzE, particles[i].PxPyPzE)
+			}
+		}
+	}
+}
+
 func loadParticles(name string) ([]fastjet.Jet, error) {
 	f, err := os.Open(name)
 	if err != nil {
@@ -4,4 +4,114 @@
 
 package fastjet
 
-type AreaDefinition struct{}
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// AreaDefinition describes how jet areas are computed.
+//
+// Only active areas are supported: the area of a jet is measured by adding
+// many infinitely soft ghost particles to the event, clustering them together
+// with the real particles, and counting the ghosts that end up in that jet.
+type AreaDefinition struct {
+	ghosts GhostedAreaSpec
+}
+
+// NewAreaDefinition returns an active area definition using the given ghosts.
+func NewAreaDefinition(ghosts GhostedAreaSpec) AreaDefinition {
+	return AreaDefinition{ghosts: ghosts}
+}
+
+// GhostSpec returns the description of the ghosts used to compute jet areas.
+func (def AreaDefinition) GhostSpec() GhostedAreaSpec {
+	return def.ghosts
+}
+
+// GhostedAreaSpec describes the ghost particles added to an event
+// to compute jet areas.
+//
+// Ghosts are placed on a grid in the (rapidity, phi) plane, with cells
+// of (approximately) GhostArea, and randomly displaced within their cell.
+//
+// The areas of the jets fluctuate with the random placement of the ghosts.
+// With Repeat > 1, the event is clustered with Repeat different placements
+// of the ghosts, and the areas are averaged over these placements.
+type GhostedAreaSpec struct {
+	RapMax      float64 // ghosts cover |y| < RapMax
+	GhostArea   float64 // area of each ghost in the (y, phi) plane
+	GridScatter float64 // fraction of a grid cell by which ghosts are randomly displaced
+	PtScatter   float64 // fractional random fluctuation of the ghosts transverse momentum
+	MeanGhostPt float64 // mean transverse momentum of the ghosts
+	Seed        int64   // seed of the random numbers used to place the ghosts
+	Repeat      int     // number of placements of the ghosts the areas are averaged over
+}
+
+// NewGhostedAreaSpec returns a ghost specification covering |y| < rapmax,
+// with the same default parameters than FastJet.
+//
+// Note that the number of ghosts grows as 1/GhostArea, and that the
+// clustering of the ghosts together with the particles of the event may
+// then be slow.
+func NewGhostedAreaSpec(rapmax float64) GhostedAreaSpec {
+	return GhostedAreaSpec{
+		RapMax:      rapmax,
+		GhostArea:   0.01,
+		GridScatter: 1.0,
+		PtScatter:   0.1,
+		MeanGhostPt: 1e-100,
+		Repeat:      1,
+	}
+}
+
+func (spec GhostedAreaSpec) validate() error {
+	if spec.RapMax <= 0 {
+		return fmt.Errorf("fastjet: invalid ghosts maximum rapidity (%v)", spec.RapMax)
+	}
+	if spec.GhostArea <= 0 {
+		return fmt.Errorf("fastjet: invalid ghost area (%v)", spec.GhostArea)
+	}
+	if spec.MeanGhostPt <= 0 {
+		return fmt.Errorf("fastjet: invalid ghost mean transverse momentum (%v)", spec.MeanGhostPt)
+	}
+	if spec.Repeat < 0 {
+		return fmt.Errorf("fastjet: invalid number of ghost placements (%v)", spec.Repeat)
+	}
+	return nil
+}
+
+// repeat returns the number of placements of the ghosts.
+// A zero Repeat means a single placement.
+func (spec GhostedAreaSpec) repeat() int {
+	if spec.Repeat < 1 {
+		return 1
+	}
+	return spec.Repeat
+}
+
+// ghosts returns the ghost particles described by spec, randomly placed
+// using rnd, and the actual area of each of these ghosts.
+func (spec GhostedAreaSpec) ghosts(rnd *rand.Rand) ([]Jet, float64) {
+	drap := math.Sqrt(spec.GhostArea)
+	nphi := int(math.Ceil(2 * math.Pi / drap))
+	dphi := 2 * math.Pi / float64(nphi)
+	nrap := int(math.Ceil(spec.RapMax / drap))
+	drap = spec.RapMax / float64(nrap)
+
+	ghosts := make([]Jet, 0, (2*nrap+1)*nphi)
+	for irap := -nrap; irap <= nrap; irap++ {
+		for iphi := 0; iphi < nphi; iphi++ {
+			phi := (float64(iphi)+0.5)*dphi + dphi*(rnd.Float64()-0.5)*spec.GridScatter
+			rap := float64(irap)*drap + drap*(rnd.Float64()-0.5)*spec.GridScatter
+			pt := spec.MeanGhostPt * (1 + (rnd.Float64()-0.5)*spec.PtScatter)
+			ghosts = append(ghosts, NewJet(
+				pt*math.Cos(phi),
+				pt*math.Sin(phi),
+				pt*math.Sinh(rap),
+				pt*math.Cosh(rap),
+			))
+		}
+	}
+	return ghosts, drap * dphi
+}
@@ -10,7 +10,13 @@ type Builder interface {
 	// the inclusive algorithm) with pt >= ptmin
 	InclusiveJets(ptmin float64) ([]Jet, error)
 
-	// ExclusiveJets
+	// ExclusiveJets returns all jets obtained when the clustering
+	// is stopped once all the remaining distances are larger than dcut
+	ExclusiveJets(dcut float64) ([]Jet, error)
+
+	// ExclusiveJetsN returns the jets obtained when the clustering
+	// is stopped once exactly n jets remain
+	ExclusiveJetsN(n int) ([]Jet, error)
 
 	// Constituents retrieves the constituents of a jet
 	Constituents(jet *Jet) ([]Jet, error)
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"go-hep.org/x/hep/fastjet/internal/heap"
 	"go-hep.org/x/hep/fmom"
@@ -23,12 +24,27 @@ type history struct {
 	maxdij  float64
 }
 
+// Special indices used in the clustering history.
 const (
-	invalidIndex     = -3
-	inexistentParent = -2
-	beamJetIndex     = -1
+	InvalidIndex     = -3 // index of a missing child or jet
+	InexistentParent = -2 // parent index of the original particles
+	BeamJetIndex     = -1 // second parent index of a recombination with the beam
 )
 
+// HistoryElement describes a step of the clustering history.
+//
+// The first elements of the history are the original particles,
+// the following ones are the recombinations, in the order in which
+// they were performed.
+type HistoryElement struct {
+	Parent1 int     // history index of the first parent, or InexistentParent
+	Parent2 int     // history index of the second parent, BeamJetIndex or InexistentParent
+	Child   int     // history index of the step where this jet is recombined, or InvalidIndex
+	Jet     int     // index in Jets of the jet created at this step, or InvalidIndex
+	Dij     float64 // distance at which this step happened
+	MaxDij  float64 // largest distance of this step and all the previous ones
+}
+
 type ClusterSequence struct {
 	def      JetDefinition
 	alg      JetAlgorithm
@@ -44,6 +60,12 @@ type ClusterSequence struct {
 	structure JetStructure
 }
 
+// NewClusterSequence clusters the particles jets according to the jet definition.
+//
+// NewClusterSequence returns an error identifying the first particle with a
+// NaN or infinite 4-momentum component, or with a non-positive energy.
+// Particles with a zero transverse momentum are accepted: their rapidity is
+// set to ±MaxRap (shifted by |pz|) when they are massless.
 func NewClusterSequence(jets []Jet, def JetDefinition) (*ClusterSequence, error) {
 	var err error
 	cs := &ClusterSequence{
@@ -72,6 +94,31 @@ func NewClusterSequence(jets []Jet, def JetDefinition) (*ClusterSequence, error)
 	return cs, err
 }
 
+// History returns the clustering history, one element for each
+// original particle followed by one element per recombination step.
+func (cs *ClusterSequence) History() []HistoryElement {
+	hs := make([]HistoryElement, len(cs.history))
+	for i, h := range cs.history {
+		hs[i] = HistoryElement{
+			Parent1: h.parent1,
+			Parent2: h.parent2,
+			Child:   h.child,
+			Jet:     h.jet,
+			Dij:     h.dij,
+			MaxDij:  h.maxdij,
+		}
+	}
+	return hs
+}
+
+// Jets returns all the jets of the clustering sequence: the original
+// particles followed by the jets created by each pairwise recombination.
+func (cs *ClusterSequence) Jets() []Jet {
+	jets := make([]Jet, len(cs.jets))
+	copy(jets, cs.jets)
+	return jets
+}
+
 // NumExclusiveJets returns the number of exclusive jets that would have been obtained
 // running the algorithm in exclusive mode with the given dcut
 func (cs *ClusterSequence) NumExclusiveJets(dcut float64) int {
@@ -87,17 +134,27 @@ func (cs *ClusterSequence) NumExclusiveJets(dcut float64) int {
 	return njets
 }
 
+// ExclusiveJets returns the jets obtained by stopping the clustering
+// once all the remaining distances (dij and diB) are larger than dcut.
 func (cs *ClusterSequence) ExclusiveJets(dcut float64) ([]Jet, error) {
 	njets := cs.NumExclusiveJets(dcut)
 	return cs.ExclusiveJetsUpTo(njets)
 }
 
-func (cs *ClusterSequence) ExclusiveJetsUpTo(njets int) ([]Jet, error) {
-	var err error
+// ExclusiveJetsN returns the jets obtained by stopping the clustering
+// once exactly njets jets remain.
+// ExclusiveJetsN returns an error if there are fewer than njets particles.
+func (cs *ClusterSequence) ExclusiveJetsN(njets int) ([]Jet, error) {
 	if njets > cs.initn {
-		err = errors.New("fastjet: requested too many exclusive jets")
-		return nil, err
+		return nil, fmt.Errorf("fastjet: requested %d exclusive jets, but there were only %d particles", njets, cs.initn)
 	}
+	return cs.ExclusiveJetsUpTo(njets)
+}
+
+// ExclusiveJetsUpTo returns the jets obtained by stopping the clustering
+// once njets jets remain, or all the particles if there are fewer than njets particles.
+func (cs *ClusterSequence) ExclusiveJetsUpTo(njets int) ([]Jet, error) {
+	var err error
 	// calculate the point where we have to stop the clustering
 	// relation between stoppt, njets assumes one extra jet disappears
 	// at each clustering
@@ -131,6 +188,10 @@ func (cs *ClusterSequence) ExclusiveJetsUpTo(njets int) ([]Jet, error) {
 	return ljets, err
 }
 
+// InclusiveJets returns all jets (in the sense of the inclusive algorithm)
+// with pt >= ptmin.
+// The jets are sorted by decreasing pt, jets with equal pt keeping their
+// clustering order.
 func (cs *ClusterSequence) InclusiveJets(ptmin float64) ([]Jet, error) {
 	var err error
 	dcut := ptmin * ptmin
@@ -146,7 +207,7 @@ func (cs *ClusterSequence) InclusiveJets(ptmin float64) ([]Jet, error) {
 			if cs.history[i].maxdij < dcut {
 				break
 			}
-			if hh := cs.history[i]; hh.parent2 == beamJetIndex && hh.dij >= dcut {
+			if hh := cs.history[i]; hh.parent2 == BeamJetIndex && hh.dij >= dcut {
 				// for beam jets
 				jets = append(jets, cs.jets[cs.history[hh.parent1].jet])
 			}
@@ -156,7 +217,7 @@ func (cs *ClusterSequence) InclusiveJets(ptmin float64) ([]Jet, error) {
 			// inclusive jets are all at the end of clustering sequence in the
 			// cambridge algorithm.
 			// if we find a non-exclusive jet, exit.
-			if cs.history[i].parent2 != beamJetIndex {
+			if cs.history[i].parent2 != BeamJetIndex {
 				break
 			}
 			parent1 := cs.history[i].parent1
@@ -173,7 +234,7 @@ func (cs *ClusterSequence) InclusiveJets(ptmin float64) ([]Jet, error) {
 		// ordering of the dij, etc...)
 		for ; 0 <= i; i-- {
 			hh := cs.history[i]
-			if hh.parent2 != beamJetIndex {
+			if hh.parent2 != BeamJetIndex {
 				continue
 			}
 			parent1 := hh.parent1
@@ -183,6 +244,7 @@ func (cs *ClusterSequence) InclusiveJets(ptmin float64) ([]Jet, error) {
 			}
 		}
 	}
+	sort.Stable(ByPt(jets))
 	return jets, err
 }
 
@@ -193,11 +255,15 @@ func (cs *ClusterSequence) init() error {
 
 	for i := range cs.jets {
 		jet := &cs.jets[i]
+		err = validateParticle(i, jet)
+		if err != nil {
+			return err
+		}
 		cs.history = append(cs.history,
 			history{
-				parent1: inexistentParent,
-				parent2: inexistentParent,
-				child:   invalidIndex,
+				parent1: InexistentParent,
+				parent2: InexistentParent,
+				child:   InvalidIndex,
 				jet:     i,
 				dij:     0.0,
 				maxdij:  0.0,
@@ -221,6 +287,23 @@ func (cs *ClusterSequence) init() error {
 	return err
 }
 
+// validateParticle checks the 4-momentum of the i-th input particle:
+// all its components must be finite and its energy must be positive.
+func validateParticle(i int, jet *Jet) error {
+	for _, v := range []float64{jet.Px(), jet.Py(), jet.Pz(), jet.E()} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf(
+				"fastjet: invalid particle %d: non-finite 4-momentum (px=%v, py=%v, pz=%v, e=%v)",
+				i, jet.Px(), jet.Py(), jet.Pz(), jet.E(),
+			)
+		}
+	}
+	if jet.E() <= 0 {
+		return fmt.Errorf("fastjet: invalid particle %d: non-positive energy (e=%v)", i, jet.E())
+	}
+	return nil
+}
+
 func (cs *ClusterSequence) run() error {
 	var err error
 	// nothing to run when event is empty
@@ -228,8 +311,19 @@ func (cs *ClusterSequence) run() error {
 		return err
 	}
 
-	// FIXME
-	err = cs.runN3Dumb()
+	switch cs.strategy {
+	case N2TiledStrategy, N2PoorTiledStrategy, N2MinHeapTiledStrategy:
+		switch cs.alg {
+		case EeKtAlgorithm, EeGenKtAlgorithm:
+			// e+e- algorithms do not use the rapidity-azimuth plane.
+			err = cs.runN3Dumb()
+		default:
+			err = cs.runN2Tiled()
+		}
+	default:
+		// FIXME: implement the NlnN strategies.
+		err = cs.runN3Dumb()
+	}
 	if err != nil {
 		return err
 	}
@@ -250,9 +344,9 @@ func (cs *ClusterSequence) addConstituents(jet *Jet) ([]Jet, error) {
 	i := jet.hidx
 	hh := &cs.history[i]
 	parent1 := hh.parent1
-	if parent1 == inexistentParent {
+	if parent1 == InexistentParent {
 		// It is an original particle (labelled by its parent having value
-		// inexistentParent), therefore add it on to the subjet vector
+		// InexistentParent), therefore add it on to the subjet vector
 		// Note: we add the initial particle and not simply 'jet' so that
 		//       calling addConstituents with a subtracted jet containing
 		//       only one particle will work.
@@ -269,7 +363,7 @@ func (cs *ClusterSequence) addConstituents(jet *Jet) ([]Jet, error) {
 
 	// see if parent2 is a real jet, then add its constituents
 	parent2 := hh.parent2
-	if parent2 == beamJetIndex {
+	if parent2 == BeamJetIndex {
 		return subjets, err
 	}
 
@@ -282,6 +376,11 @@ func (cs *ClusterSequence) addConstituents(jet *Jet) ([]Jet, error) {
 	return subjets, err
 }
 
+// jetScaleForAlgorithm returns the momentum scale of the jet entering
+// the distances of the clustering algorithm.
+// Scales involving an inverse or a non-positive power of the transverse
+// momentum (or energy) are clamped, so particles with a zero transverse
+// momentum get a finite (large) distance instead of an infinite one.
 func (cs *ClusterSequence) jetScaleForAlgorithm(jet *Jet) float64 {
 	switch cs.alg {
 
@@ -363,7 +462,7 @@ func (cs *ClusterSequence) ijRecombinationStep(i, j int, dij float64) (int, erro
 
 func (cs *ClusterSequence) ibRecombinationStep(i int, dib float64) error {
 	k := len(cs.history)
-	err := cs.addStepToHistory(k, cs.jets[i].hidx, beamJetIndex, invalidIndex, dib)
+	err := cs.addStepToHistory(k, cs.jets[i].hidx, BeamJetIndex, InvalidIndex, dib)
 	return err
 }
 
@@ -375,7 +474,7 @@ func (cs *ClusterSequence) addStepToHistory(istep, i1, i2, idx int, dij float64)
 			parent1: i1,
 			parent2: i2,
 			jet:     idx,
-			child:   invalidIndex,
+			child:   InvalidIndex,
 			dij:     dij,
 			maxdij:  math.Max(dij, cs.history[len(cs.history)-1].maxdij),
 		},
@@ -393,7 +492,7 @@ func (cs *ClusterSequence) addStepToHistory(istep, i1, i2, idx int, dij float64)
 	}
 
 	// get cross-referencing right
-	if idx != invalidIndex {
+	if idx != InvalidIndex {
 		cs.jets[idx].hidx = step
 		cs.setStructure(&cs.jets[idx])
 	}
@@ -401,6 +500,17 @@ func (cs *ClusterSequence) addStepToHistory(istep, i1, i2, idx int, dij float64)
 	return err
 }
 
+// pairLess reports whether the pair of jets (i1, j1) comes before
+// the pair (i2, j2), comparing the lowest indices first.
+func pairLess(i1, j1, i2, j2 int) bool {
+	i1, j1 = imin(i1, j1), imax(i1, j1)
+	i2, j2 = imin(i2, j2), imax(i2, j2)
+	if i1 != i2 {
+		return i1 < i2
+	}
+	return j1 < j2
+}
+
 // runs the N3Dumb strategy
 func (cs *ClusterSequence) runN3Dumb() error {
 	var err error
@@ -423,18 +533,22 @@ func (cs *ClusterSequence) runN3Dumb() error {
 	for n := njets; n > 0; n-- {
 		ii := 0
 		jj := -2
-		// find smallest beam distance
+		// find smallest beam distance.
+		// ties are broken by choosing the jet with the lowest index,
+		// so the clustering doesn't depend on the order of the jets slice.
 		ymin := cs.jetScaleForAlgorithm(jets[0].jet)
 		for i := 0; i < n; i++ {
 			y := cs.jetScaleForAlgorithm(jets[i].jet)
-			if y < ymin {
+			if y < ymin || (y == ymin && jets[i].idx < jets[ii].idx) {
 				ymin = y
 				ii = i
 				jj = -2
 			}
 		}
 
-		// find smallest distance between pair of jets
+		// find smallest distance between pair of jets.
+		// beam distances win ties with pair distances, and ties between
+		// pairs are broken by choosing the pair with the lowest indices.
 		for i := 0; i < n-1; i++ {
 			ijet := jets[i].jet
 			for j := i + 1; j < n; j++ {
@@ -460,7 +574,7 @@ func (cs *ClusterSequence) runN3Dumb() error {
 				default:
 					y = jetscale * Distance(ijet, jjet) * cs.invR2
 				}
-				if y < ymin {
+				if y < ymin || (y == ymin && jj >= 0 && pairLess(jets[i].idx, jets[j].idx, jets[ii].idx, jets[jj].idx)) {
 					ymin = y
 					ii = i
 					jj = j
@@ -527,12 +641,12 @@ func (cs *ClusterSequence) runNlnN() error {
 func (cs *ClusterSequence) addKtDistance(h *heap.Heap, jeti, jetj int, dist float64) {
 	yiB := cs.jetScaleForAlgorithm(&cs.jets[jeti])
 	if yiB == 0 {
-		h.Push(jeti, beamJetIndex, yiB)
+		h.Push(jeti, BeamJetIndex, yiB)
 		return
 	}
 	deltaR2 := dist * cs.invR2
 	if deltaR2 > 1 {
-		h.Push(jeti, beamJetIndex, yiB)
+		h.Push(jeti, BeamJetIndex, yiB)
 		return
 	}
 	if yiB <= cs.jetScaleForAlgorithm(&cs.jets[jetj]) {
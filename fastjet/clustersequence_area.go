@@ -4,44 +4,254 @@
 
 package fastjet
 
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ClusterSequenceArea clusters particles together with ghosts, so
+// the area of the resulting jets can be computed.
 type ClusterSequenceArea struct {
-	cs   *ClusterSequence
+	cs   *ClusterSequence // clustering of the particles and the ghosts
 	area AreaDefinition
+
+	n         int     // number of particles, ghosts come after them in the sequence
+	ghostArea float64 // actual area of each ghost
+
+	// areas of the inclusive jets obtained with the other placements
+	// of the ghosts, indexed by the key of their real constituents.
+	areas []map[string]float64
 }
 
 func NewClusterSequenceArea(jets []Jet, def JetDefinition, area AreaDefinition) (*ClusterSequenceArea, error) {
-	cs, err := NewClusterSequence(jets, def)
+	spec := area.GhostSpec()
+	err := spec.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(spec.Seed))
+	ghosts, ghostArea := spec.ghosts(rnd)
+	particles := make([]Jet, 0, len(jets)+len(ghosts))
+	particles = append(particles, jets...)
+	particles = append(particles, ghosts...)
+
+	cs, err := NewClusterSequence(particles, def)
 	if err != nil {
 		return nil, err
 	}
 
 	csa := ClusterSequenceArea{
-		cs:   cs,
-		area: area,
+		cs:        cs,
+		area:      area,
+		n:         len(jets),
+		ghostArea: ghostArea,
+	}
+
+	for i := 1; i < spec.repeat(); i++ {
+		ghosts, _ := spec.ghosts(rnd)
+		particles = append(particles[:len(jets)], ghosts...)
+		cs, err := NewClusterSequence(particles, def)
+		if err != nil {
+			return nil, err
+		}
+		incl, err := cs.InclusiveJets(0)
+		if err != nil {
+			return nil, err
+		}
+		areas := make(map[string]float64, len(incl))
+		for j := range incl {
+			key, n, err := csa.split(cs, &incl[j])
+			if err != nil {
+				return nil, err
+			}
+			if key == "" {
+				continue
+			}
+			areas[key] = float64(n) * ghostArea
+		}
+		csa.areas = append(csa.areas, areas)
 	}
+
 	return &csa, nil
 }
 
+// Area returns the active area of the jet: the number of ghosts
+// clustered into the jet times the area of a ghost.
+//
+// When the ghosts are placed more than once, the area is averaged over
+// the placements.
+// The jet is matched to the inclusive jets obtained with the other
+// placements by its real (non-ghost) constituents. Placements without
+// a matching inclusive jet are left out of the average.
 func (csa *ClusterSequenceArea) Area(jet *Jet) float64 {
-	panic("not implemented")
+	area, _ := csa.areaStats(jet)
+	return area
 }
 
+// AreaErr returns the uncertainty on the area of the jet, estimated from
+// the spread of the areas obtained with the different placements of the ghosts.
+// It is zero when the ghosts are placed only once.
 func (csa *ClusterSequenceArea) AreaErr(jet *Jet) float64 {
-	panic("not implemented")
+	_, err := csa.areaStats(jet)
+	return err
 }
 
+// areaStats returns the mean area of the jet over the placements of the
+// ghosts, and the uncertainty on that mean.
+func (csa *ClusterSequenceArea) areaStats(jet *Jet) (mean, err float64) {
+	key, n, e := csa.split(csa.cs, jet)
+	if e != nil {
+		panic(e)
+	}
+
+	area := float64(n) * csa.ghostArea
+	var (
+		sum  = area
+		sum2 = area * area
+		m    = 1.0
+	)
+	for _, areas := range csa.areas {
+		area, ok := areas[key]
+		if !ok {
+			continue
+		}
+		sum += area
+		sum2 += area * area
+		m++
+	}
+
+	mean = sum / m
+	if m > 1 {
+		err = math.Sqrt(math.Abs(sum2/m-mean*mean) / (m - 1))
+	}
+	return mean, err
+}
+
+// split returns the key identifying the real constituents of the jet
+// clustered by cs, and the number of ghosts clustered into the jet.
+// The key of a jet made only of ghosts is empty.
+func (csa *ClusterSequenceArea) split(cs *ClusterSequence, jet *Jet) (string, int, error) {
+	subjets, err := cs.Constituents(jet)
+	if err != nil {
+		return "", 0, err
+	}
+	var (
+		n   int
+		ids []int
+	)
+	for i := range subjets {
+		if csa.isGhost(&subjets[i]) {
+			n++
+			continue
+		}
+		ids = append(ids, subjets[i].hidx)
+	}
+	sort.Ints(ids)
+
+	var key strings.Builder
+	for i, idx := range ids {
+		if i > 0 {
+			key.WriteByte(',')
+		}
+		key.WriteString(strconv.Itoa(idx))
+	}
+	return key.String(), n, nil
+}
+
+// NumExclusiveJets returns the number of exclusive jets that would have been
+// obtained running the algorithm in exclusive mode with the given dcut,
+// leaving out jets made only of ghosts.
 func (csa *ClusterSequenceArea) NumExclusiveJets(dcut float64) int {
-	panic("not implemented")
+	jets, err := csa.ExclusiveJets(dcut)
+	if err != nil {
+		panic(err)
+	}
+	return len(jets)
 }
 
-func (cs *ClusterSequenceArea) ExclusiveJets(dcut float64) ([]Jet, error) {
-	panic("not implemented")
+// ExclusiveJets returns the jets obtained by stopping the clustering
+// once all the remaining distances (dij and diB) are larger than dcut,
+// leaving out jets made only of ghosts.
+func (csa *ClusterSequenceArea) ExclusiveJets(dcut float64) ([]Jet, error) {
+	jets, err := csa.cs.ExclusiveJets(dcut)
+	if err != nil {
+		return nil, err
+	}
+	return csa.dropPureGhosts(jets)
 }
 
-func (cs *ClusterSequenceArea) ExclusiveJetsUpTo(njets int) ([]Jet, error) {
-	panic("not implemented")
+// ExclusiveJetsN returns the jets obtained by stopping the clustering
+// once exactly njets jets remain, leaving out jets made only of ghosts.
+// Ghosts take part in the clustering: fewer than njets jets may thus be returned.
+func (csa *ClusterSequenceArea) ExclusiveJetsN(njets int) ([]Jet, error) {
+	jets, err := csa.cs.ExclusiveJetsN(njets)
+	if err != nil {
+		return nil, err
+	}
+	return csa.dropPureGhosts(jets)
 }
 
+// ExclusiveJetsUpTo returns the jets obtained by stopping the clustering
+// once njets jets remain, leaving out jets made only of ghosts.
+func (csa *ClusterSequenceArea) ExclusiveJetsUpTo(njets int) ([]Jet, error) {
+	jets, err := csa.cs.ExclusiveJetsUpTo(njets)
+	if err != nil {
+		return nil, err
+	}
+	return csa.dropPureGhosts(jets)
+}
+
+// InclusiveJets returns all jets (in the sense of the inclusive algorithm)
+// with pt >= ptmin, leaving out jets made only of ghosts.
+// The jets are sorted by decreasing pt.
+// The constituents of the returned jets include the ghosts they contain.
 func (csa *ClusterSequenceArea) InclusiveJets(ptmin float64) ([]Jet, error) {
-	panic("not implemented")
+	jets, err := csa.cs.InclusiveJets(ptmin)
+	if err != nil {
+		return nil, err
+	}
+	return csa.dropPureGhosts(jets)
+}
+
+// Constituents retrieves the constituents of a jet, including its ghosts.
+func (csa *ClusterSequenceArea) Constituents(jet *Jet) ([]Jet, error) {
+	return csa.cs.Constituents(jet)
+}
+
+// dropPureGhosts removes the jets made only of ghosts, in place.
+func (csa *ClusterSequenceArea) dropPureGhosts(jets []Jet) ([]Jet, error) {
+	o := jets[:0]
+	for i := range jets {
+		pure, err := csa.isPureGhost(&jets[i])
+		if err != nil {
+			return nil, err
+		}
+		if !pure {
+			o = append(o, jets[i])
+		}
+	}
+	return o, nil
+}
+
+// isGhost returns whether the original particle jet is a ghost.
+func (csa *ClusterSequenceArea) isGhost(jet *Jet) bool {
+	return jet.hidx >= csa.n
+}
+
+// isPureGhost returns whether the jet is only made of ghosts.
+func (csa *ClusterSequenceArea) isPureGhost(jet *Jet) (bool, error) {
+	subjets, err := csa.cs.Constituents(jet)
+	if err != nil {
+		return false, err
+	}
+	for i := range subjets {
+		if !csa.isGhost(&subjets[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
 }
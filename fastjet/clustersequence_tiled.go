@@ -0,0 +1,292 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+import (
+	"math"
+)
+
+const (
+	tileMinSize = 0.1 // smallest size of a tile in the rapidity-azimuth plane
+	tileMaxRap  = 10  // tiles cover at most |y| < tileMaxRap, farther jets go into the edge tiles
+)
+
+// tiling divides the rapidity-azimuth plane into tiles larger than
+// the jet radius, so the jets closer than the radius to a given jet
+// are all in the neighbouring tiles of the tile holding that jet.
+type tiling struct {
+	ymin float64 // lower edge of the first rapidity tile
+	ysz  float64 // size of the tiles along the rapidity
+	ny   int     // number of tiles along the rapidity
+	psz  float64 // size of the tiles along the azimuth
+	np   int     // number of tiles along the azimuth
+
+	tiles      [][]int // indices of the jets held by each tile
+	neighbours [][]int // indices of the neighbouring tiles of each tile, including itself
+}
+
+func newTiling(jets []Jet, r float64) *tiling {
+	// tiles are made slightly larger than the jet radius, so jets in
+	// tiles which are not neighbours are always farther than the radius,
+	// even after rounding.
+	size := math.Max(r, tileMinSize) * (1 + 1e-6)
+
+	ymin := math.Inf(+1)
+	ymax := math.Inf(-1)
+	for i := range jets {
+		y := jets[i].Rapidity()
+		ymin = math.Min(ymin, y)
+		ymax = math.Max(ymax, y)
+	}
+	ymin = math.Max(ymin, -tileMaxRap)
+	ymax = math.Min(ymax, +tileMaxRap)
+	if ymax < ymin {
+		ymin, ymax = 0, 0
+	}
+
+	t := &tiling{
+		ymin: ymin,
+		ysz:  size,
+		ny:   imax(1, int(math.Ceil((ymax-ymin)/size))),
+		np:   imax(1, int(2*math.Pi/size)),
+	}
+	t.psz = 2 * math.Pi / float64(t.np)
+	t.tiles = make([][]int, t.ny*t.np)
+	t.neighbours = make([][]int, t.ny*t.np)
+
+	for iy := 0; iy < t.ny; iy++ {
+		for ip := 0; ip < t.np; ip++ {
+			tile := iy*t.np + ip
+			for jy := imax(0, iy-1); jy <= imin(t.ny-1, iy+1); jy++ {
+			loop:
+				for dp := -1; dp <= +1; dp++ {
+					jp := (ip + dp + t.np) % t.np
+					n := jy*t.np + jp
+					for _, o := range t.neighbours[tile] {
+						if o == n {
+							continue loop
+						}
+					}
+					t.neighbours[tile] = append(t.neighbours[tile], n)
+				}
+			}
+		}
+	}
+
+	return t
+}
+
+// tile returns the index of the tile holding the jet.
+func (t *tiling) tile(jet *Jet) int {
+	iy := int(math.Floor((jet.Rapidity() - t.ymin) / t.ysz))
+	iy = imax(0, imin(t.ny-1, iy))
+
+	phi := jet.Phi()
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+	ip := int(phi / t.psz)
+	ip = imax(0, imin(t.np-1, ip))
+
+	return iy*t.np + ip
+}
+
+func (t *tiling) add(tile, i int) {
+	t.tiles[tile] = append(t.tiles[tile], i)
+}
+
+func (t *tiling) remove(tile, i int) {
+	jets := t.tiles[tile]
+	for k, j := range jets {
+		if j == i {
+			jets[k] = jets[len(jets)-1]
+			t.tiles[tile] = jets[:len(jets)-1]
+			return
+		}
+	}
+}
+
+// tiledJet is a jet being clustered by the tiled strategy.
+type tiledJet struct {
+	idx   int     // index of the jet in the cluster sequence, -1 once clustered
+	scale float64 // momentum scale of the jet for the clustering algorithm
+	tile  int     // index of the tile holding the jet
+	nn    int     // index of the geometrically closest tiled jet, -1 if none
+	dist  float64 // rapidity-azimuth distance to the closest tiled jet
+	dij   float64 // clustering distance to the closest tiled jet
+}
+
+// runN2Tiled runs the clustering, only looking for the closest jets in
+// the neighbouring tiles of the rapidity-azimuth plane.
+//
+// runN2Tiled keeps track of the geometrically closest jet (in the
+// rapidity-azimuth plane) of each jet, and only updates it for the jets
+// affected by a recombination step.
+// As in FastJet, the smallest clustering distance dij is always found
+// between a jet and its geometrically closest jet: jets with a small
+// momentum scale (e.g. soft ghosts with the anti-kt algorithm) thus do
+// not all have to be updated each time a jet with a large momentum
+// scale is recombined.
+// The clustering sequence is identical to the one of runN3Dumb, ties
+// being broken the same way.
+func (cs *ClusterSequence) runN2Tiled() error {
+	var err error
+	t := newTiling(cs.jets, cs.r)
+
+	jets := make([]tiledJet, len(cs.jets))
+	for i := range jets {
+		jet := &cs.jets[i]
+		jets[i] = tiledJet{
+			idx:   i,
+			scale: cs.jetScaleForAlgorithm(jet),
+			tile:  t.tile(jet),
+			nn:    -1,
+		}
+		t.add(jets[i].tile, i)
+	}
+
+	// dist returns the rapidity-azimuth and clustering distances between
+	// the tiled jets i and j.
+	dist := func(i, j int) (float64, float64) {
+		ii := &jets[i]
+		jj := &jets[j]
+		jetscale := math.Min(ii.scale, jj.scale)
+		d := Distance(&cs.jets[ii.idx], &cs.jets[jj.idx])
+		return d, jetscale * d * cs.invR2
+	}
+
+	// closer reports whether the tiled jet j is closer to i than the
+	// closest jet of i known so far.
+	// ties in the rapidity-azimuth distance are broken by the clustering
+	// distance, then with the same rules than runN3Dumb.
+	closer := func(i, j int, d, dij float64) bool {
+		ii := &jets[i]
+		switch {
+		case ii.nn < 0 || d < ii.dist:
+			return true
+		case d > ii.dist:
+			return false
+		case dij != ii.dij:
+			return dij < ii.dij
+		}
+		return pairLess(ii.idx, jets[j].idx, ii.idx, jets[ii.nn].idx)
+	}
+
+	// update looks for the closest jet of i in its neighbouring tiles.
+	update := func(i int) {
+		ii := &jets[i]
+		ii.nn = -1
+		for _, tile := range t.neighbours[ii.tile] {
+			for _, j := range t.tiles[tile] {
+				if j == i {
+					continue
+				}
+				d, dij := dist(i, j)
+				if closer(i, j, d, dij) {
+					ii.nn = j
+					ii.dist = d
+					ii.dij = dij
+				}
+			}
+		}
+	}
+
+	for i := range jets {
+		update(i)
+	}
+
+	for n := len(jets); n > 0; n-- {
+		ii := -1
+		jj := -2
+		var ymin float64
+
+		// find smallest beam distance, then the smallest distance between
+		// pairs of jets, with the same tie-breaking rules than runN3Dumb.
+		for i := range jets {
+			jet := &jets[i]
+			if jet.idx < 0 {
+				continue
+			}
+			if ii < 0 || jet.scale < ymin || (jet.scale == ymin && jet.idx < jets[ii].idx) {
+				ymin = jet.scale
+				ii = i
+			}
+		}
+
+		for i := range jets {
+			jet := &jets[i]
+			if jet.idx < 0 || jet.nn < 0 {
+				continue
+			}
+			if jet.dij < ymin || (jet.dij == ymin && jj >= 0 && pairLess(jet.idx, jets[jet.nn].idx, jets[ii].idx, jets[jj].idx)) {
+				ymin = jet.dij
+				ii = i
+				jj = jet.nn
+			}
+		}
+
+		// now recombine
+		t.remove(jets[ii].tile, ii)
+		if jj >= 0 {
+			// combine pair
+			nn, err := cs.ijRecombinationStep(jets[ii].idx, jets[jj].idx, ymin)
+			if err != nil {
+				return err
+			}
+			t.remove(jets[jj].tile, jj)
+			jets[jj].idx = -1
+
+			// the new jet takes the place of ii.
+			jet := &cs.jets[nn]
+			jets[ii] = tiledJet{
+				idx:   nn,
+				scale: cs.jetScaleForAlgorithm(jet),
+				tile:  t.tile(jet),
+				nn:    -1,
+			}
+			t.add(jets[ii].tile, ii)
+		} else {
+			// combine ii with beam
+			err = cs.ibRecombinationStep(jets[ii].idx, ymin)
+			if err != nil {
+				return err
+			}
+			jets[ii].idx = -1
+		}
+
+		// update the jets which were closest to the recombined ones.
+		for i := range jets {
+			jet := &jets[i]
+			if jet.idx < 0 || i == ii {
+				continue
+			}
+			if jet.nn == ii || (jj >= 0 && jet.nn == jj) {
+				update(i)
+			}
+		}
+
+		if jj < 0 {
+			continue
+		}
+
+		// the new jet may be the closest jet of its neighbours.
+		update(ii)
+		for _, tile := range t.neighbours[jets[ii].tile] {
+			for _, j := range t.tiles[tile] {
+				if j == ii {
+					continue
+				}
+				d, dij := dist(j, ii)
+				if closer(j, ii, d, dij) {
+					jets[j].nn = ii
+					jets[j].dist = d
+					jets[j].dij = dij
+				}
+			}
+		}
+	}
+
+	return err
+}
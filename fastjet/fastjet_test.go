@@ -5,11 +5,15 @@
 package fastjet_test
 
 import (
+	"fmt"
+	"math"
+	"reflect"
 	"sort"
 	"testing"
 
 	"go-hep.org/x/hep/fastjet"
 	"go-hep.org/x/hep/fmom"
+	"gonum.org/v1/gonum/floats"
 )
 
 func TestSimple(t *testing.T) {
@@ -115,3 +119,505 @@ func TestSimple(t *testing.T) {
 		}
 	}
 }
+
+func TestRecombiner(t *testing.T) {
+	t.Parallel()
+
+	// massless jets at y=0, with (pt, phi).
+	j1 := masslessJet(3, 0, 0)
+	j2 := masslessJet(1, 0, 0.4)
+
+	for _, tc := range []struct {
+		scheme fastjet.RecombinationScheme
+		want   fastjet.Jet
+	}{
+		{
+			scheme: fastjet.EScheme,
+			want:   fastjet.NewJet(j1.Px()+j2.Px(), j1.Py()+j2.Py(), 0, j1.E()+j2.E()),
+		},
+		{
+			scheme: fastjet.PtScheme,
+			want:   masslessJet(4, 0, 0.1),
+		},
+		{
+			scheme: fastjet.WTAPtScheme,
+			want:   masslessJet(4, 0, 0),
+		},
+	} {
+		t.Run(tc.scheme.String(), func(t *testing.T) {
+			rec := fastjet.NewRecombiner(tc.scheme)
+			if got, want := rec.Scheme(), tc.scheme; got != want {
+				t.Fatalf("invalid scheme: got=%v, want=%v", got, want)
+			}
+			for _, jets := range [][2]fastjet.Jet{{j1, j2}, {j2, j1}} {
+				got, err := rec.Recombine(&jets[0], &jets[1])
+				if err != nil {
+					t.Fatalf("could not recombine: %+v", err)
+				}
+				if !fmom.Equal(&got, &tc.want) {
+					t.Fatalf("invalid recombined jet:\ngot= %v\nwant=%v", got.PxPyPzE, tc.want.PxPyPzE)
+				}
+			}
+		})
+	}
+}
+
+func TestJetKinematics(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		jet  fastjet.Jet
+		pt   float64
+		eta  float64
+		rap  float64
+		phi  float64
+		m    float64
+		e    float64
+	}{
+		{
+			name: "transverse",
+			jet:  fastjet.NewJet(3, 4, 0, 13),
+			pt:   5,
+			eta:  0,
+			rap:  0,
+			phi:  math.Atan2(4, 3),
+			m:    12,
+			e:    13,
+		},
+		{
+			name: "generic",
+			jet:  fastjet.NewJet(1, 1, 2, 4),
+			pt:   math.Sqrt2,
+			eta:  math.Asinh(math.Sqrt2),
+			rap:  0.5 * math.Log(3),
+			phi:  math.Pi / 4,
+			m:    math.Sqrt(10),
+			e:    4,
+		},
+		{
+			name: "negative-phi",
+			jet:  fastjet.NewJet(0, -2, 0, 2),
+			pt:   2,
+			eta:  0,
+			rap:  0,
+			phi:  -math.Pi / 2,
+			m:    0,
+			e:    2,
+		},
+		{
+			name: "at-rest",
+			jet:  fastjet.NewJet(0, 0, 0, 2),
+			pt:   0,
+			eta:  0,
+			rap:  0,
+			phi:  0,
+			m:    2,
+			e:    2,
+		},
+		{
+			name: "along-beam",
+			jet:  fastjet.NewJet(0, 0, -5, 5),
+			pt:   0,
+			eta:  math.Inf(-1),
+			rap:  -(fastjet.MaxRap + 5),
+			phi:  0,
+			m:    0,
+			e:    5,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			jet := &tc.jet
+			for _, v := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"pt", jet.Pt(), tc.pt},
+				{"pt2", jet.Pt2(), tc.pt * tc.pt},
+				{"eta", jet.Eta(), tc.eta},
+				{"rapidity", jet.Rapidity(), tc.rap},
+				{"phi", jet.Phi(), tc.phi},
+				{"m", jet.M(), tc.m},
+				{"e", jet.E(), tc.e},
+			} {
+				if v.got != v.want && !floats.EqualWithinAbsOrRel(v.got, v.want, 1e-12, 1e-12) {
+					t.Errorf("invalid %s: got=%v, want=%v", v.name, v.got, v.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewJetPtYPhiM(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pt, y, phi, m float64
+	}{
+		{pt: 10, y: 0, phi: 0, m: 0},
+		{pt: 25, y: 1.5, phi: 2, m: 4.2},
+		{pt: 3, y: -2.5, phi: -1, m: 0.1},
+		{pt: 50, y: 0.3, phi: -3, m: 91.2},
+		{pt: 1e-3, y: 4, phi: 0.5, m: 1e-3},
+	} {
+		t.Run(fmt.Sprintf("pt=%v-y=%v-phi=%v-m=%v", tc.pt, tc.y, tc.phi, tc.m), func(t *testing.T) {
+			jet := fastjet.NewJetPtYPhiM(tc.pt, tc.y, tc.phi, tc.m)
+			for _, v := range []struct {
+				name      string
+				got, want float64
+				tol       float64
+			}{
+				{"pt", jet.Pt(), tc.pt, 1e-12},
+				{"rapidity", jet.Rapidity(), tc.y, 1e-12},
+				{"phi", jet.Phi(), tc.phi, 1e-12},
+				// the mass suffers from cancellations between e and p.
+				{"m", jet.M(), tc.m, 1e-6},
+			} {
+				if !floats.EqualWithinAbsOrRel(v.got, v.want, v.tol, v.tol) {
+					t.Errorf("invalid %s: got=%v, want=%v", v.name, v.got, v.want)
+				}
+			}
+
+			ref := fastjet.NewJetPxPyPzE(jet.Px(), jet.Py(), jet.Pz(), jet.E())
+			if !fmom.Equal(&ref, &jet) {
+				t.Fatalf("invalid 4-momentum:\ngot= %v\nwant=%v", jet.PxPyPzE, ref.PxPyPzE)
+			}
+		})
+	}
+}
+
+func TestUserIndex(t *testing.T) {
+	t.Parallel()
+
+	particles := []fastjet.Jet{
+		fastjet.NewJet(+99.0, +0.1, 0, 100.0),
+		fastjet.NewJet(+04.0, -0.1, 0, 005.0),
+		fastjet.NewJet(-99.0, +0.0, 0, 099.0),
+		fastjet.NewJet(+99.0, +0.1, 0, 199.0),
+		fastjet.NewJet(-99.0, +0.0, 0, 299.0),
+		fastjet.NewJet(-99.0, +1.0, 0, 399.0),
+		fastjet.NewJet(+50.0, +1.0, 100, 399.0),
+	}
+	for i := range particles {
+		if got, want := particles[i].UserIndex(), -1; got != want {
+			t.Fatalf("invalid default user index: got=%d, want=%d", got, want)
+		}
+		particles[i].SetUserIndex(10 + i)
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 0.7, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatalf("clustering failed: %v", err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatalf("could not retrieve inclusive jets: %v", err)
+	}
+	if got, want := len(jets), 2; got != want {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+	}
+
+	seen := make(map[int]int)
+	for i := range jets {
+		jet := &jets[i]
+		if got, want := jet.UserIndex(), -1; got != want {
+			t.Fatalf("jet[%d]: invalid user index: got=%d, want=%d", i, got, want)
+		}
+		for j, cst := range jet.Constituents() {
+			idx := cst.UserIndex() - 10
+			if idx < 0 || idx >= len(particles) {
+				t.Fatalf("jet[%d].constituent[%d]: invalid user index %d", i, j, cst.UserIndex())
+			}
+			if !fmom.Equal(&particles[idx], &cst) {
+				t.Fatalf("jet[%d].constituent[%d]: user index %d does not match particle:\ngot: %v\nwant:%v",
+					i, j, cst.UserIndex(), cst.PxPyPzE, particles[idx].PxPyPzE,
+				)
+			}
+			seen[idx]++
+		}
+	}
+	for i := range particles {
+		if seen[i] != 1 {
+			t.Fatalf("particle %d found %d times in jets constituents", i, seen[i])
+		}
+	}
+}
+
+// masslessJet returns a massless jet with the given transverse momentum,
+// rapidity and azimuthal angle.
+func masslessJet(pt, y, phi float64) fastjet.Jet {
+	return fastjet.NewJet(pt*math.Cos(phi), pt*math.Sin(phi), pt*math.Sinh(y), pt*math.Cosh(y))
+}
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	particles := []fastjet.Jet{
+		fastjet.NewJet(+99.0, +0.1, 0, 100.0),
+		fastjet.NewJet(+04.0, -0.1, 0, 005.0),
+		fastjet.NewJet(-99.0, +0.0, 0, 099.0),
+		fastjet.NewJet(+99.0, +0.1, 0, 199.0),
+		fastjet.NewJet(-99.0, +0.0, 0, 299.0),
+		fastjet.NewJet(-99.0, +1.0, 0, 399.0),
+		fastjet.NewJet(+50.0, +1.0, 100, 399.0),
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 0.7, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatalf("clustering failed: %v", err)
+	}
+
+	var (
+		n    = len(particles)
+		hist = cs.History()
+		jets = cs.Jets()
+	)
+
+	if got, want := len(hist), 2*n; got != want {
+		t.Fatalf("invalid history length: got=%d, want=%d", got, want)
+	}
+
+	for i := 0; i < n; i++ {
+		h := hist[i]
+		if h.Parent1 != fastjet.InexistentParent || h.Parent2 != fastjet.InexistentParent {
+			t.Fatalf("history[%d]: invalid parents for original particle: %+v", i, h)
+		}
+		if got, want := h.Jet, i; got != want {
+			t.Fatalf("history[%d]: invalid jet index: got=%d, want=%d", i, got, want)
+		}
+		if !fmom.Equal(&jets[h.Jet], &particles[i]) {
+			t.Fatalf("history[%d]: invalid jet:\ngot: %v\nwant:%v", i, jets[h.Jet].PxPyPzE, particles[i].PxPyPzE)
+		}
+	}
+
+	var (
+		nbeam  = 0
+		maxdij = 0.0
+	)
+	for i := n; i < len(hist); i++ {
+		h := hist[i]
+		if h.Parent1 < 0 || h.Parent1 >= i {
+			t.Fatalf("history[%d]: invalid first parent %d", i, h.Parent1)
+		}
+		if got := hist[h.Parent1].Child; got != i {
+			t.Fatalf("history[%d]: invalid child of first parent: got=%d, want=%d", i, got, i)
+		}
+		maxdij = math.Max(maxdij, h.Dij)
+		if got, want := h.MaxDij, maxdij; got != want {
+			t.Fatalf("history[%d]: invalid max dij: got=%v, want=%v", i, got, want)
+		}
+
+		if h.Parent2 == fastjet.BeamJetIndex {
+			nbeam++
+			if got, want := h.Jet, fastjet.InvalidIndex; got != want {
+				t.Fatalf("history[%d]: invalid jet index for beam recombination: got=%d, want=%d", i, got, want)
+			}
+			continue
+		}
+
+		if h.Parent2 <= h.Parent1 || h.Parent2 >= i {
+			t.Fatalf("history[%d]: invalid second parent %d", i, h.Parent2)
+		}
+		p1 := jets[hist[h.Parent1].Jet]
+		p2 := jets[hist[h.Parent2].Jet]
+		want := fmom.NewPxPyPzE(p1.Px()+p2.Px(), p1.Py()+p2.Py(), p1.Pz()+p2.Pz(), p1.E()+p2.E())
+		if !fmom.Equal(&jets[h.Jet], &want) {
+			t.Fatalf("history[%d]: invalid recombined jet:\ngot: %v\nwant:%v", i, jets[h.Jet].PxPyPzE, want)
+		}
+	}
+
+	inclusive, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatalf("could not retrieve inclusive jets: %v", err)
+	}
+	if got, want := nbeam, len(inclusive); got != want {
+		t.Fatalf("invalid number of beam recombinations: got=%d, want=%d", got, want)
+	}
+	if got, want := len(jets), 2*n-nbeam; got != want {
+		t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+	}
+}
+
+func TestTieBreaking(t *testing.T) {
+	t.Parallel()
+
+	// all the pairs of particles are at the same (zero) distance:
+	// ties are broken by recombining the pairs with the lowest indices first.
+	particles := []fastjet.Jet{
+		fastjet.NewJet(10, 0, 0, 10),
+		fastjet.NewJet(10, 0, 0, 10),
+		fastjet.NewJet(10, 0, 0, 10),
+		fastjet.NewJet(10, 0, 0, 10),
+	}
+
+	want := [][2]int{
+		{0, 1},
+		{2, 3},
+		{4, 5},
+		{6, fastjet.BeamJetIndex},
+	}
+
+	for _, tc := range []struct {
+		name string
+		alg  fastjet.JetAlgorithm
+	}{
+		{"kt", fastjet.KtAlgorithm},
+		{"cambridge", fastjet.CambridgeAlgorithm},
+		{"antikt", fastjet.AntiKtAlgorithm},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			def := fastjet.NewJetDefinition(tc.alg, 0.4, fastjet.EScheme, fastjet.BestStrategy)
+			var ref []fastjet.HistoryElement
+			for i := 0; i < 10; i++ {
+				cs, err := fastjet.NewClusterSequence(particles, def)
+				if err != nil {
+					t.Fatalf("clustering failed: %v", err)
+				}
+				hist := cs.History()
+				if i == 0 {
+					ref = hist
+					continue
+				}
+				if !reflect.DeepEqual(hist, ref) {
+					t.Fatalf("clustering #%d differs:\ngot: %+v\nwant:%+v", i, hist, ref)
+				}
+			}
+
+			steps := ref[len(particles):]
+			if got, want := len(steps), len(want); got != want {
+				t.Fatalf("invalid number of steps: got=%d, want=%d", got, want)
+			}
+			for i, step := range steps {
+				if got := [2]int{step.Parent1, step.Parent2}; got != want[i] {
+					t.Fatalf("step %d: invalid parents: got=%v, want=%v", i, got, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInclusiveJetsSorted(t *testing.T) {
+	t.Parallel()
+
+	// well separated particles, each of them making a jet.
+	var particles []fastjet.Jet
+	for i, pt := range []float64{20, 50, 10, 40, 30, 60} {
+		phi := float64(i) * math.Pi / 3
+		px, py := pt*math.Cos(phi), pt*math.Sin(phi)
+		particles = append(particles, fastjet.NewJet(px, py, 0, pt))
+	}
+
+	for _, tc := range []struct {
+		name string
+		alg  fastjet.JetAlgorithm
+	}{
+		{"kt", fastjet.KtAlgorithm},
+		{"cambridge", fastjet.CambridgeAlgorithm},
+		{"antikt", fastjet.AntiKtAlgorithm},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			def := fastjet.NewJetDefinition(tc.alg, 0.4, fastjet.EScheme, fastjet.BestStrategy)
+			cs, err := fastjet.NewClusterSequence(particles, def)
+			if err != nil {
+				t.Fatalf("clustering failed: %v", err)
+			}
+
+			jets, err := cs.InclusiveJets(15)
+			if err != nil {
+				t.Fatalf("could not retrieve inclusive jets: %v", err)
+			}
+
+			want := []float64{60, 50, 40, 30, 20}
+			if got, want := len(jets), len(want); got != want {
+				t.Fatalf("invalid number of jets: got=%d, want=%d", got, want)
+			}
+			for i := range jets {
+				if got, want := jets[i].Pt(), want[i]; math.Abs(got-want) > 1e-12 {
+					t.Fatalf("jet[%d]: invalid pt: got=%v, want=%v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidParticles(t *testing.T) {
+	t.Parallel()
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 0.4, fastjet.EScheme, fastjet.BestStrategy)
+	for _, tc := range []struct {
+		name string
+		jet  fastjet.Jet
+		err  string
+	}{
+		{
+			name: "nan",
+			jet:  fastjet.NewJet(1, math.NaN(), 0, 2),
+			err:  "fastjet: invalid particle 1: non-finite 4-momentum (px=1, py=NaN, pz=0, e=2)",
+		},
+		{
+			name: "inf",
+			jet:  fastjet.NewJet(1, 0, math.Inf(+1), math.Inf(+1)),
+			err:  "fastjet: invalid particle 1: non-finite 4-momentum (px=1, py=0, pz=+Inf, e=+Inf)",
+		},
+		{
+			name: "negative-energy",
+			jet:  fastjet.NewJet(1, 0, 0, -1),
+			err:  "fastjet: invalid particle 1: non-positive energy (e=-1)",
+		},
+		{
+			name: "zero-energy",
+			jet:  fastjet.NewJet(0, 0, 0, 0),
+			err:  "fastjet: invalid particle 1: non-positive energy (e=0)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			particles := []fastjet.Jet{
+				fastjet.NewJet(10, 0, 0, 10),
+				tc.jet,
+				fastjet.NewJet(0, 10, 0, 10),
+			}
+			_, err := fastjet.NewClusterSequence(particles, def)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+
+	// particles along the beam axis have a zero transverse momentum.
+	particles := []fastjet.Jet{
+		fastjet.NewJet(10, 0, 0, 10),
+		fastjet.NewJet(0, 0, 5, 5),
+		fastjet.NewJet(0, 0, 0, 1),
+	}
+	for _, alg := range []fastjet.JetAlgorithm{
+		fastjet.KtAlgorithm,
+		fastjet.CambridgeAlgorithm,
+		fastjet.AntiKtAlgorithm,
+	} {
+		def := fastjet.NewJetDefinition(alg, 0.4, fastjet.EScheme, fastjet.BestStrategy)
+		cs, err := fastjet.NewClusterSequence(particles, def)
+		if err != nil {
+			t.Fatalf("could not cluster zero-pt particles: %+v", err)
+		}
+		jets, err := cs.InclusiveJets(0)
+		if err != nil {
+			t.Fatalf("could not retrieve inclusive jets: %+v", err)
+		}
+		var e float64
+		for _, jet := range jets {
+			if math.IsNaN(jet.Pt()) || math.IsNaN(jet.Rapidity()) || math.IsNaN(jet.Phi()) {
+				t.Fatalf("invalid jet: %v", jet.PxPyPzE)
+			}
+			e += jet.E()
+		}
+		if got, want := e, 16.0; got != want {
+			t.Fatalf("invalid total energy: got=%v, want=%v", got, want)
+		}
+	}
+}
@@ -24,6 +24,7 @@ type Jet struct {
 	fmom.PxPyPzE
 
 	UserInfo  UserInfo // holds extra user information for this Jet
+	uidx      int      // user index, -1 when not set
 	hidx      int      // cluster sequence history index
 	structure JetStructure
 
@@ -34,15 +35,41 @@ type Jet struct {
 	phi float64
 }
 
+// NewJet returns a jet with the given 4-momentum components.
 func NewJet(px, py, pz, e float64) Jet {
 	jet := Jet{
 		PxPyPzE: fmom.NewPxPyPzE(px, py, pz, e),
+		uidx:    -1,
 		hidx:    -1,
 	}
 	jet.setupCache()
 	return jet
 }
 
+// NewJetPxPyPzE returns a jet with the given 4-momentum components.
+// It is equivalent to NewJet.
+func NewJetPxPyPzE(px, py, pz, e float64) Jet {
+	return NewJet(px, py, pz, e)
+}
+
+// NewJetPtYPhiM returns a jet with the given transverse momentum,
+// rapidity, azimuthal angle and mass.
+//
+// The 4-momentum of the jet is:
+//
+//   (pt cos(phi), pt sin(phi), mt sinh(y), mt cosh(y))
+//
+// with mt = sqrt(pt^2 + m^2) the transverse mass.
+func NewJetPtYPhiM(pt, y, phi, m float64) Jet {
+	mt := math.Hypot(pt, m)
+	return NewJet(
+		pt*math.Cos(phi),
+		pt*math.Sin(phi),
+		mt*math.Sinh(y),
+		mt*math.Cosh(y),
+	)
+}
+
 func (jet *Jet) setupCache() {
 	pt := jet.Pt()
 	jet.pt2 = pt * pt
@@ -78,6 +105,21 @@ func (jet *Jet) Rapidity() float64 {
 	return jet.rap
 }
 
+// UserIndex returns the user index of the jet.
+//
+// The user index is -1 unless set with SetUserIndex.
+// The user index of input particles is kept by the constituents of the
+// clustered jets, while jets made of several particles have a user index of -1.
+func (jet *Jet) UserIndex() int {
+	return jet.uidx
+}
+
+// SetUserIndex sets the user index of the jet, e.g. to link the jet back to
+// the detector object or truth particle it was built from.
+func (jet *Jet) SetUserIndex(i int) {
+	jet.uidx = i
+}
+
 // Constituents returns the list of constituents for this jet.
 func (jet *Jet) Constituents() []Jet {
 	subjets, err := jet.structure.Constituents(jet)
@@ -0,0 +1,63 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// nsubjettinessR is the radius used to find the N-subjettiness axes.
+// It is large enough for the exclusive kt clustering never to merge
+// particles with the beam.
+const nsubjettinessR = 1000
+
+// NSubjettiness returns the N-subjettiness tau_N of the jet, with the
+// angular exponent beta and the characteristic jet radius R0:
+//
+//  tau_N = sum_i pt_i * min_k(ΔR_{i,k})^beta / (pt_jet * R0^beta)
+//
+// where the sum runs over the jet constituents and the n axes are the
+// exclusive kt subjets of these constituents.
+// Ratios such as tau2/tau1 are obtained by calling NSubjettiness twice.
+//
+// The jet must come from a cluster sequence.
+func NSubjettiness(n int, jet *Jet, beta, R0 float64) (float64, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("fastjet: invalid number of N-subjettiness axes (%d)", n)
+	}
+	if jet.structure == nil {
+		return 0, errors.New("fastjet: could not compute N-subjettiness of jet without clustering structure")
+	}
+
+	constituents, err := jet.structure.Constituents(jet)
+	if err != nil {
+		return 0, fmt.Errorf("fastjet: could not retrieve jet constituents: %w", err)
+	}
+
+	def := NewJetDefinition(KtAlgorithm, nsubjettinessR, EScheme, BestStrategy)
+	cs, err := NewClusterSequence(constituents, def)
+	if err != nil {
+		return 0, fmt.Errorf("fastjet: could not recluster jet constituents: %w", err)
+	}
+
+	axes, err := cs.ExclusiveJetsUpTo(n)
+	if err != nil {
+		return 0, fmt.Errorf("fastjet: could not retrieve N-subjettiness axes: %w", err)
+	}
+
+	tau := 0.0
+	for i := range constituents {
+		cst := &constituents[i]
+		dr2 := math.Inf(+1)
+		for k := range axes {
+			dr2 = math.Min(dr2, Distance(cst, &axes[k]))
+		}
+		tau += cst.Pt() * math.Pow(dr2, 0.5*beta)
+	}
+
+	return tau / (jet.Pt() * math.Pow(R0, beta)), nil
+}
@@ -0,0 +1,119 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+func TestNSubjettiness(t *testing.T) {
+	t.Parallel()
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy)
+
+	for _, tc := range []struct {
+		name      string
+		particles []fastjet.Jet
+		ratio     func(tau21 float64) bool
+	}{
+		{
+			// massless particles at y=0, with (pt, phi):
+			// two narrow prongs, 0.6 apart.
+			name: "two-prongs",
+			particles: []fastjet.Jet{
+				masslessJet(50, 0, 0),
+				masslessJet(20, 0.02, 0.01),
+				masslessJet(10, -0.01, -0.02),
+				masslessJet(40, 0, 0.6),
+				masslessJet(15, 0.01, 0.62),
+				masslessJet(5, -0.02, 0.59),
+			},
+			ratio: func(tau21 float64) bool { return tau21 < 0.1 },
+		},
+		{
+			// a single wide prong.
+			name: "one-prong",
+			particles: []fastjet.Jet{
+				masslessJet(50, 0, 0),
+				masslessJet(20, 0.2, 0.1),
+				masslessJet(20, -0.1, -0.2),
+				masslessJet(20, 0.15, -0.1),
+				masslessJet(20, -0.2, 0.15),
+			},
+			ratio: func(tau21 float64) bool { return tau21 > 0.3 },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := fastjet.NewClusterSequence(tc.particles, def)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			jets, err := cs.InclusiveJets(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jets) != 1 {
+				t.Fatalf("got %d jets, want 1", len(jets))
+			}
+			jet := &jets[0]
+
+			tau1, err := fastjet.NSubjettiness(1, jet, 1, 1)
+			if err != nil {
+				t.Fatalf("could not compute tau1: %+v", err)
+			}
+			tau2, err := fastjet.NSubjettiness(2, jet, 1, 1)
+			if err != nil {
+				t.Fatalf("could not compute tau2: %+v", err)
+			}
+			if tau1 <= 0 || tau2 > tau1 {
+				t.Fatalf("invalid N-subjettiness: tau1=%v, tau2=%v", tau1, tau2)
+			}
+			if tau21 := tau2 / tau1; !tc.ratio(tau21) {
+				t.Fatalf("invalid tau2/tau1: %v", tau21)
+			}
+
+			n := len(tc.particles)
+			tauN, err := fastjet.NSubjettiness(n, jet, 1, 1)
+			if err != nil {
+				t.Fatalf("could not compute tau%d: %+v", n, err)
+			}
+			if tauN != 0 {
+				t.Fatalf("invalid tau%d: got=%v, want=0", n, tauN)
+			}
+		})
+	}
+
+	// a single particle jet is its own axis: tau1 vanishes.
+	cs, err := fastjet.NewClusterSequence([]fastjet.Jet{masslessJet(10, 0, 0)}, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tau1, err := fastjet.NSubjettiness(1, &jets[0], 1, 1)
+	if err != nil {
+		t.Fatalf("could not compute tau1: %+v", err)
+	}
+	if math.Abs(tau1) > 1e-12 {
+		t.Fatalf("invalid tau1 for single particle jet: %v", tau1)
+	}
+
+	_, err = fastjet.NSubjettiness(0, &jets[0], 1, 1)
+	if err == nil {
+		t.Fatalf("expected an error for n=0")
+	}
+
+	jet := fastjet.NewJet(1, 0, 0, 1)
+	_, err = fastjet.NSubjettiness(1, &jet, 1, 1)
+	if err == nil {
+		t.Fatalf("expected an error for a jet without clustering structure")
+	}
+}
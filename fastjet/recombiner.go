@@ -46,6 +46,25 @@ func (rec DefaultRecombiner) Recombine(j1, j2 *Jet) (Jet, error) {
 			j1.E()+j2.E(),
 		), nil
 
+	case WTAPtScheme:
+		// the recombined jet has the direction and mass of the harder jet,
+		// and the scalar sum of the transverse momenta.
+		hard := j1
+		if j2.Pt2() > j1.Pt2() {
+			hard = j2
+		}
+		pt := j1.Pt() + j2.Pt()
+		m := hard.M()
+		mt := math.Sqrt(pt*pt + m*m)
+		y := hard.Rapidity()
+		phi := hard.Phi()
+		return NewJet(
+			pt*math.Cos(phi),
+			pt*math.Sin(phi),
+			mt*math.Sinh(y),
+			mt*math.Cosh(y),
+		), nil
+
 	case PtScheme, EtScheme, BIPtScheme:
 		w1 = j1.Pt()
 		w2 = j2.Pt()
@@ -84,7 +103,7 @@ func (rec DefaultRecombiner) Recombine(j1, j2 *Jet) (Jet, error) {
 func (rec DefaultRecombiner) Preprocess(jet *Jet) error {
 
 	switch rec.Scheme() {
-	case EScheme, BIPtScheme, BIPt2Scheme:
+	case EScheme, BIPtScheme, BIPt2Scheme, WTAPtScheme:
 		return nil
 
 	case PtScheme, Pt2Scheme:
@@ -20,6 +20,7 @@ const (
 	Et2Scheme
 	BIPtScheme
 	BIPt2Scheme
+	WTAPtScheme // winner-takes-all: direction and mass of the harder jet, sum of pt
 
 	ExternalScheme RecombinationScheme = 99
 )
@@ -40,6 +41,8 @@ func (s RecombinationScheme) String() string {
 		return "BIPt"
 	case BIPt2Scheme:
 		return "BIPt2"
+	case WTAPtScheme:
+		return "WTAPt"
 
 	case ExternalScheme:
 		return "External"
@@ -0,0 +1,147 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+import (
+	"math"
+	"sort"
+)
+
+// Selector selects a subset of jets out of a collection of jets.
+//
+// Selectors are combined with And, Or and Not.
+// As in FastJet, combined selectors are all applied to the same
+// input collection: And selects the jets selected by both selectors,
+// even when one of them (e.g. SelectNHardest) depends on the whole
+// collection.
+//
+// The zero value of Selector selects all jets.
+type Selector struct {
+	fct func(jets []Jet) []bool // fct returns whether each jet is selected
+}
+
+// mask returns whether each jet is selected.
+func (sel Selector) mask(jets []Jet) []bool {
+	if sel.fct == nil {
+		mask := make([]bool, len(jets))
+		for i := range mask {
+			mask[i] = true
+		}
+		return mask
+	}
+	return sel.fct(jets)
+}
+
+// Apply returns the selected jets, in the same order as the input ones.
+func (sel Selector) Apply(jets []Jet) []Jet {
+	mask := sel.mask(jets)
+	out := make([]Jet, 0, len(jets))
+	for i, ok := range mask {
+		if ok {
+			out = append(out, jets[i])
+		}
+	}
+	return out
+}
+
+// And returns a selector selecting jets selected by both sel and o.
+func (sel Selector) And(o Selector) Selector {
+	return Selector{
+		fct: func(jets []Jet) []bool {
+			mask := sel.mask(jets)
+			omsk := o.mask(jets)
+			for i := range mask {
+				mask[i] = mask[i] && omsk[i]
+			}
+			return mask
+		},
+	}
+}
+
+// Or returns a selector selecting jets selected by sel or o.
+func (sel Selector) Or(o Selector) Selector {
+	return Selector{
+		fct: func(jets []Jet) []bool {
+			mask := sel.mask(jets)
+			omsk := o.mask(jets)
+			for i := range mask {
+				mask[i] = mask[i] || omsk[i]
+			}
+			return mask
+		},
+	}
+}
+
+// Not returns a selector selecting jets not selected by sel.
+func (sel Selector) Not() Selector {
+	return Selector{
+		fct: func(jets []Jet) []bool {
+			mask := sel.mask(jets)
+			for i := range mask {
+				mask[i] = !mask[i]
+			}
+			return mask
+		},
+	}
+}
+
+// newJetSelector returns a selector selecting jets one by one,
+// according to the pass function.
+func newJetSelector(pass func(jet *Jet) bool) Selector {
+	return Selector{
+		fct: func(jets []Jet) []bool {
+			mask := make([]bool, len(jets))
+			for i := range jets {
+				mask[i] = pass(&jets[i])
+			}
+			return mask
+		},
+	}
+}
+
+// SelectPtMin selects jets with pt >= ptmin.
+func SelectPtMin(ptmin float64) Selector {
+	return newJetSelector(func(jet *Jet) bool {
+		return jet.Pt() >= ptmin
+	})
+}
+
+// SelectAbsRapMax selects jets with |rapidity| <= rapmax.
+func SelectAbsRapMax(rapmax float64) Selector {
+	return newJetSelector(func(jet *Jet) bool {
+		return math.Abs(jet.Rapidity()) <= rapmax
+	})
+}
+
+// SelectNHardest selects the n jets with the largest pt.
+// Jets with equal pt are selected in the order of the input collection.
+func SelectNHardest(n int) Selector {
+	return Selector{
+		fct: func(jets []Jet) []bool {
+			mask := make([]bool, len(jets))
+			if n <= 0 {
+				return mask
+			}
+			if n >= len(jets) {
+				for i := range mask {
+					mask[i] = true
+				}
+				return mask
+			}
+
+			idx := make([]int, len(jets))
+			for i := range idx {
+				idx[i] = i
+			}
+			sort.SliceStable(idx, func(i, j int) bool {
+				return jets[idx[j]].Pt() < jets[idx[i]].Pt()
+			})
+			for _, i := range idx[:n] {
+				mask[i] = true
+			}
+			return mask
+		},
+	}
+}
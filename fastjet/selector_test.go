@@ -0,0 +1,109 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+func TestSelector(t *testing.T) {
+	t.Parallel()
+
+	// massless jets at phi=0, with (pt, y).
+	jets := []fastjet.Jet{
+		masslessJet(10, 0.5, 0),
+		masslessJet(50, -3, 0),
+		masslessJet(30, 1, 0),
+		masslessJet(5, 0, 0),
+		masslessJet(30, -2, 0),
+	}
+
+	for _, tc := range []struct {
+		name string
+		sel  fastjet.Selector
+		want []int // indices of the selected jets
+	}{
+		{
+			name: "ptmin",
+			sel:  fastjet.SelectPtMin(10),
+			want: []int{0, 1, 2, 4},
+		},
+		{
+			name: "absrapmax",
+			sel:  fastjet.SelectAbsRapMax(1),
+			want: []int{0, 2, 3},
+		},
+		{
+			name: "nhardest",
+			sel:  fastjet.SelectNHardest(2),
+			want: []int{1, 2},
+		},
+		{
+			name: "nhardest-stable",
+			sel:  fastjet.SelectNHardest(3),
+			want: []int{1, 2, 4},
+		},
+		{
+			name: "nhardest-all",
+			sel:  fastjet.SelectNHardest(10),
+			want: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "nhardest-none",
+			sel:  fastjet.SelectNHardest(0),
+			want: []int{},
+		},
+		{
+			name: "and",
+			sel:  fastjet.SelectPtMin(10).And(fastjet.SelectAbsRapMax(1)),
+			want: []int{0, 2},
+		},
+		{
+			// both selectors are applied to the whole collection.
+			name: "and-nhardest",
+			sel:  fastjet.SelectAbsRapMax(1).And(fastjet.SelectNHardest(2)),
+			want: []int{2},
+		},
+		{
+			name: "or",
+			sel:  fastjet.SelectPtMin(40).Or(fastjet.SelectAbsRapMax(0.2)),
+			want: []int{1, 3},
+		},
+		{
+			name: "not",
+			sel:  fastjet.SelectPtMin(10).Not(),
+			want: []int{3},
+		},
+		{
+			name: "zero",
+			sel:  fastjet.Selector{},
+			want: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "zero-and",
+			sel:  fastjet.Selector{}.And(fastjet.SelectPtMin(10)),
+			want: []int{0, 1, 2, 4},
+		},
+		{
+			name: "zero-not",
+			sel:  fastjet.Selector{}.Not(),
+			want: []int{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.sel.Apply(jets)
+			want := make([]fastjet.Jet, len(tc.want))
+			for i, j := range tc.want {
+				want[i] = jets[j]
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid selection:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxAllowableR is the jet radius used to recluster all the constituents
+// of a jet into a single jet.
+const maxAllowableR = 1000
+
+// SoftDrop returns the jet groomed by soft drop.
+//
+// The constituents of the jet are reclustered with the Cambridge/Aachen
+// algorithm into a single jet.
+// The last recombination of the jet is then undone, giving two subjets
+// which must satisfy the soft drop condition:
+//
+//   min(pt1, pt2) / (pt1 + pt2) > zcut * (ΔR12 / R0)^beta
+//
+// where ΔR12 is the rapidity-azimuth distance between the two subjets.
+// When the condition fails, the softer subjet is dropped and the
+// declustering goes on with the harder one.
+// A jet which can not be declustered any further (a single particle) is
+// returned as is.
+//
+// The jet must come from a cluster sequence.
+// The groomed jet is associated with the Cambridge/Aachen cluster sequence,
+// so its constituents can be retrieved.
+func SoftDrop(jet *Jet, zcut, beta, R0 float64) (Jet, error) {
+	if jet.structure == nil {
+		return Jet{}, errors.New("fastjet: could not soft drop jet without clustering structure")
+	}
+	if R0 <= 0 {
+		return Jet{}, fmt.Errorf("fastjet: invalid soft drop radius R0=%v", R0)
+	}
+
+	constituents, err := jet.structure.Constituents(jet)
+	if err != nil {
+		return Jet{}, fmt.Errorf("fastjet: could not retrieve jet constituents: %w", err)
+	}
+
+	def := NewJetDefinition(CambridgeAlgorithm, maxAllowableR, EScheme, BestStrategy)
+	cs, err := NewClusterSequence(constituents, def)
+	if err != nil {
+		return Jet{}, fmt.Errorf("fastjet: could not recluster jet constituents: %w", err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		return Jet{}, fmt.Errorf("fastjet: could not retrieve reclustered jet: %w", err)
+	}
+	if len(jets) != 1 {
+		return Jet{}, fmt.Errorf("fastjet: invalid number of reclustered jets (got=%d, want=1)", len(jets))
+	}
+
+	i := jets[0].hidx
+	for {
+		hh := cs.history[i]
+		if hh.parent1 == InexistentParent {
+			break
+		}
+
+		var (
+			j1  = &cs.jets[cs.history[hh.parent1].jet]
+			j2  = &cs.jets[cs.history[hh.parent2].jet]
+			pt1 = j1.Pt()
+			pt2 = j2.Pt()
+			dr  = math.Sqrt(Distance(j1, j2))
+			z   = math.Min(pt1, pt2) / (pt1 + pt2)
+		)
+		if z > zcut*math.Pow(dr/R0, beta) {
+			break
+		}
+
+		i = hh.parent1
+		if pt2 > pt1 {
+			i = hh.parent2
+		}
+	}
+
+	return cs.jets[cs.history[i].jet], nil
+}
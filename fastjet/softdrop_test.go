@@ -0,0 +1,106 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fmom"
+)
+
+func TestSoftDrop(t *testing.T) {
+	t.Parallel()
+
+	// massless particles at y=0, with (pt, phi):
+	// a two-prong jet with a soft wide-angle addition.
+	particles := []fastjet.Jet{
+		// prongs
+		masslessJet(100, 0, 0),
+		masslessJet(80, 0, 0.5),
+		// soft addition
+		masslessJet(1, 0, -0.6),
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jets) != 1 {
+		t.Fatalf("got %d jets, want 1", len(jets))
+	}
+	jet := &jets[0]
+
+	for _, tc := range []struct {
+		name  string
+		zcut  float64
+		beta  float64
+		want  fastjet.Jet
+		nsubs int
+	}{
+		{
+			name: "soft-dropped",
+			zcut: 0.1,
+			beta: 0,
+			want: fastjet.NewJet(
+				particles[0].Px()+particles[1].Px(),
+				particles[0].Py()+particles[1].Py(),
+				0,
+				particles[0].E()+particles[1].E(),
+			),
+			nsubs: 2,
+		},
+		{
+			name:  "all-kept",
+			zcut:  0,
+			beta:  0,
+			want:  fastjet.NewJet(jet.Px(), jet.Py(), jet.Pz(), jet.E()),
+			nsubs: 3,
+		},
+		{
+			name:  "large-beta",
+			zcut:  0.1,
+			beta:  20,
+			want:  fastjet.NewJet(jet.Px(), jet.Py(), jet.Pz(), jet.E()),
+			nsubs: 3,
+		},
+		{
+			name:  "single-prong",
+			zcut:  0.5,
+			beta:  0,
+			want:  fastjet.NewJet(particles[0].Px(), particles[0].Py(), particles[0].Pz(), particles[0].E()),
+			nsubs: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := fastjet.SoftDrop(jet, tc.zcut, tc.beta, 1.0)
+			if err != nil {
+				t.Fatalf("could not soft drop jet: %+v", err)
+			}
+			if !fmom.Equal(&got, &tc.want) {
+				t.Fatalf("invalid groomed jet:\ngot= %v\nwant=%v", got.PxPyPzE, tc.want.PxPyPzE)
+			}
+			if got, want := len(got.Constituents()), tc.nsubs; got != want {
+				t.Fatalf("invalid number of constituents: got=%d, want=%d", got, want)
+			}
+		})
+	}
+
+	_, err = fastjet.SoftDrop(&particles[0], 0.1, 0, 1.0)
+	if err == nil {
+		t.Fatalf("expected an error soft dropping a jet without clustering structure")
+	}
+
+	_, err = fastjet.SoftDrop(jet, 0.1, 0, 0)
+	if err == nil {
+		t.Fatalf("expected an error soft dropping a jet with R0=0")
+	}
+}
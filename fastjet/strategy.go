@@ -9,6 +9,14 @@ import (
 )
 
 // Strategy defines the algorithmic strategy used while clustering.
+//
+// The tiled strategies (N2TiledStrategy, N2PoorTiledStrategy and
+// N2MinHeapTiledStrategy) divide the rapidity-azimuth plane into tiles and
+// only look for the closest jets in the neighbouring tiles, which is much
+// faster for events with many particles.
+// All the other strategies, as well as the e+e- algorithms, search all the
+// pairs of jets at each step.
+// All strategies give identical clustering sequences.
 type Strategy int
 
 const (
@@ -0,0 +1,125 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+)
+
+// randomEvent returns n massless particles, a few of them duplicated to
+// exercise the tie-breaking rules, plus particles far in the forward
+// regions and along the beam axis.
+func randomEvent(rnd *rand.Rand, n int) []fastjet.Jet {
+	particles := make([]fastjet.Jet, 0, n+n/10+3)
+	for i := 0; i < n; i++ {
+		pt := 0.5 + rnd.ExpFloat64()*10
+		y := rnd.Float64()*10 - 5
+		phi := rnd.Float64()*2*math.Pi - math.Pi
+		particles = append(particles, masslessJet(pt, y, phi))
+	}
+	for i := 0; i < n/10; i++ {
+		particles = append(particles, particles[rnd.Intn(n)])
+	}
+	particles = append(particles,
+		masslessJet(1, 12, 0),
+		masslessJet(2, -15, 1),
+		fastjet.NewJet(0, 0, 10, 10),
+	)
+	return particles
+}
+
+func TestStrategies(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1234))
+	events := [][]fastjet.Jet{
+		{fastjet.NewJet(10, 0, 0, 10)},
+		{
+			fastjet.NewJet(10, 0, 0, 10),
+			fastjet.NewJet(10, 0, 0, 10),
+			fastjet.NewJet(10, 0, 0, 10),
+			fastjet.NewJet(10, 0, 0, 10),
+		},
+	}
+	for _, n := range []int{10, 50, 200} {
+		events = append(events, randomEvent(rnd, n))
+	}
+	pp, err := loadParticles("testdata/single-pp-event.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	events = append(events, pp)
+
+	for _, tc := range []struct {
+		name  string
+		alg   fastjet.JetAlgorithm
+		extra float64
+	}{
+		{name: "kt", alg: fastjet.KtAlgorithm},
+		{name: "cambridge", alg: fastjet.CambridgeAlgorithm},
+		{name: "antikt", alg: fastjet.AntiKtAlgorithm},
+		{name: "genkt-p=0.5", alg: fastjet.GenKtAlgorithm, extra: 0.5},
+		{name: "genkt-p=-2", alg: fastjet.GenKtAlgorithm, extra: -2},
+		{name: "eekt", alg: fastjet.EeKtAlgorithm},
+	} {
+		for _, r := range []float64{0.05, 0.4, 1.0, 1.5, 4} {
+			t.Run(fmt.Sprintf("%s-r=%v", tc.name, r), func(t *testing.T) {
+				ref := fastjet.NewJetDefinitionExtra(tc.alg, r, fastjet.EScheme, fastjet.N2PlainStrategy, tc.extra)
+				def := fastjet.NewJetDefinitionExtra(tc.alg, r, fastjet.EScheme, fastjet.N2TiledStrategy, tc.extra)
+				for i, particles := range events {
+					want, err := fastjet.NewClusterSequence(particles, ref)
+					if err != nil {
+						t.Fatalf("event #%d: could not cluster with %v strategy: %+v", i, ref.Strategy(), err)
+					}
+					got, err := fastjet.NewClusterSequence(particles, def)
+					if err != nil {
+						t.Fatalf("event #%d: could not cluster with %v strategy: %+v", i, def.Strategy(), err)
+					}
+
+					if !reflect.DeepEqual(got.History(), want.History()) {
+						t.Fatalf("event #%d: clustering histories differ", i)
+					}
+
+					gjets, wjets := got.Jets(), want.Jets()
+					if len(gjets) != len(wjets) {
+						t.Fatalf("event #%d: invalid number of jets: got=%d, want=%d", i, len(gjets), len(wjets))
+					}
+					for j := range gjets {
+						if got, want := gjets[j].PxPyPzE, wjets[j].PxPyPzE; got != want {
+							t.Fatalf("event #%d: jet[%d] differ:\ngot= %v\nwant=%v", i, j, got, want)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkStrategies(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1234))
+	for _, n := range []int{100, 1000} {
+		particles := randomEvent(rnd, n)
+		for _, strategy := range []fastjet.Strategy{
+			fastjet.N2PlainStrategy,
+			fastjet.N2TiledStrategy,
+		} {
+			def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 0.4, fastjet.EScheme, strategy)
+			b.Run(fmt.Sprintf("%v-n=%d", strategy, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, err := fastjet.NewClusterSequence(particles, def)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Trim returns the jet groomed by trimming.
+//
+// The constituents of the jet are reclustered into subjets of radius subR,
+// using the algorithm, recombination scheme and strategy of def.
+// Subjets with pt < fcut * pt(jet) are discarded and the remaining ones
+// are recombined into the trimmed jet.
+// A jet whose subjets are all discarded is trimmed into a null jet.
+//
+// The jet must come from a cluster sequence.
+// The trimmed jet is not associated with a cluster sequence.
+func Trim(jet *Jet, subR, fcut float64, def JetDefinition) (Jet, error) {
+	if jet.structure == nil {
+		return Jet{}, errors.New("fastjet: could not trim jet without clustering structure")
+	}
+
+	constituents, err := jet.structure.Constituents(jet)
+	if err != nil {
+		return Jet{}, fmt.Errorf("fastjet: could not retrieve jet constituents: %w", err)
+	}
+
+	subdef := def
+	subdef.r = subR
+	cs, err := NewClusterSequence(constituents, subdef)
+	if err != nil {
+		return Jet{}, fmt.Errorf("fastjet: could not recluster jet constituents: %w", err)
+	}
+
+	subjets, err := cs.InclusiveJets(0)
+	if err != nil {
+		return Jet{}, fmt.Errorf("fastjet: could not retrieve subjets: %w", err)
+	}
+
+	var (
+		ptcut   = fcut * jet.Pt()
+		trimmed = NewJet(0, 0, 0, 0)
+		empty   = true
+	)
+	for i := range subjets {
+		subjet := &subjets[i]
+		if subjet.Pt() < ptcut {
+			continue
+		}
+		if empty {
+			trimmed = NewJet(subjet.Px(), subjet.Py(), subjet.Pz(), subjet.E())
+			empty = false
+			continue
+		}
+		trimmed, err = def.Recombiner().Recombine(&trimmed, subjet)
+		if err != nil {
+			return Jet{}, fmt.Errorf("fastjet: could not recombine subjets: %w", err)
+		}
+	}
+
+	return trimmed, nil
+}
@@ -0,0 +1,86 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastjet_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fastjet"
+	"go-hep.org/x/hep/fmom"
+)
+
+func TestTrim(t *testing.T) {
+	t.Parallel()
+
+	// massless particles at y=0, with (pt, phi):
+	// a two-prong jet with a soft blob.
+	particles := []fastjet.Jet{
+		// prongs
+		masslessJet(100, 0, 0),
+		masslessJet(80, 0, 0.5),
+		// soft blob
+		masslessJet(1, 0, -0.45),
+		masslessJet(1.5, 0, -0.47),
+		masslessJet(0.5, 0, -0.43),
+	}
+
+	def := fastjet.NewJetDefinition(fastjet.AntiKtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy)
+	cs, err := fastjet.NewClusterSequence(particles, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets, err := cs.InclusiveJets(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jets) != 1 {
+		t.Fatalf("got %d jets, want 1", len(jets))
+	}
+	jet := &jets[0]
+
+	subdef := fastjet.NewJetDefinition(fastjet.KtAlgorithm, 1.0, fastjet.EScheme, fastjet.BestStrategy)
+	for _, tc := range []struct {
+		name string
+		fcut float64
+		want fastjet.Jet
+	}{
+		{
+			name: "blob-removed",
+			fcut: 0.05,
+			want: fastjet.NewJet(
+				particles[0].Px()+particles[1].Px(),
+				particles[0].Py()+particles[1].Py(),
+				0,
+				particles[0].E()+particles[1].E(),
+			),
+		},
+		{
+			name: "all-kept",
+			fcut: 0,
+			want: fastjet.NewJet(jet.Px(), jet.Py(), jet.Pz(), jet.E()),
+		},
+		{
+			name: "all-removed",
+			fcut: 1,
+			want: fastjet.NewJet(0, 0, 0, 0),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := fastjet.Trim(jet, 0.2, tc.fcut, subdef)
+			if err != nil {
+				t.Fatalf("could not trim jet: %+v", err)
+			}
+			if !fmom.Equal(&got, &tc.want) {
+				t.Fatalf("invalid trimmed jet:\ngot= %v\nwant=%v", got.PxPyPzE, tc.want.PxPyPzE)
+			}
+		})
+	}
+
+	_, err = fastjet.Trim(&particles[0], 0.2, 0.05, subdef)
+	if err == nil {
+		t.Fatalf("expected an error trimming a jet without clustering structure")
+	}
+}
@@ -11,7 +11,10 @@ package groot
 //go:generate go run ./gen.rtree.go
 
 import (
+	"io"
+
 	"go-hep.org/x/hep/groot/riofs"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd" // register the root:// and xroot:// schemes
 	"go-hep.org/x/hep/groot/root"
 	_ "go-hep.org/x/hep/groot/ztypes"
 )
@@ -23,6 +26,8 @@ const (
 // Open opens the named ROOT file for reading. If successful, methods on the
 // returned file can be used for reading; the associated file descriptor
 // has mode os.O_RDONLY.
+//
+// Remote files may be opened over xrootd, with root:// or xroot:// URLs.
 func Open(path string) (*File, error) {
 	return riofs.Open(path)
 }
@@ -32,6 +37,12 @@ func NewReader(r Reader) (*File, error) {
 	return riofs.NewReader(r)
 }
 
+// NewReaderAt creates a new ROOT file reader, reading the size bytes
+// of the ROOT file from r.
+func NewReaderAt(r io.ReaderAt, size int64) (*File, error) {
+	return riofs.NewReaderAt(r, size)
+}
+
 // Create creates the named ROOT file for writing.
 func Create(name string, opts ...FileOption) (*File, error) {
 	return riofs.Create(name, opts...)
@@ -120,6 +120,16 @@ func kindOf(buf []byte) Kind {
 	}
 }
 
+// BlockSizes returns the compression algorithm of the block described by
+// the header hdr, together with the compressed and uncompressed sizes of that
+// block, header excluded.
+func BlockSizes(hdr []byte) (kind Kind, srcsz, tgtsz int) {
+	_ = hdr[HeaderSize-1] // bound-check
+	srcsz = int(hdr[3]) | int(hdr[4])<<8 | int(hdr[5])<<16
+	tgtsz = int(hdr[6]) | int(hdr[7])<<8 | int(hdr[8])<<16
+	return kindOf(hdr), srcsz, tgtsz
+}
+
 func rootCompressAlgLvl(v int32) (Kind, int) {
 	var (
 		alg = Kind(v / 100)
@@ -0,0 +1,171 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+)
+
+// parameterVersion is the ROOT version for TParameter<T>.
+// TParameter<T> is a class template and is thus not handled by the
+// generator of the rvers package.
+const parameterVersion = 2
+
+// Parameter is a named scalar value, as stored by ROOT's TParameter<T>.
+//
+// Parameter handles TParameter<T> for T in bool, int, long, Long64_t,
+// float and double.
+type Parameter struct {
+	obj   Object
+	class string
+	name  string
+	val   interface{}
+}
+
+// NewParameter creates a new Parameter holding the provided value.
+// v must be one of bool, int32, int64, float32 or float64.
+func NewParameter(name string, v interface{}) *Parameter {
+	var class string
+	switch v.(type) {
+	case bool:
+		class = "TParameter<bool>"
+	case int32:
+		class = "TParameter<int>"
+	case int64:
+		class = "TParameter<Long64_t>"
+	case float32:
+		class = "TParameter<float>"
+	case float64:
+		class = "TParameter<double>"
+	default:
+		panic(fmt.Errorf("rbase: invalid TParameter value type %T", v))
+	}
+	return &Parameter{
+		obj:   *NewObject(),
+		class: class,
+		name:  name,
+		val:   v,
+	}
+}
+
+func (*Parameter) RVersion() int16 {
+	return parameterVersion
+}
+
+func (p *Parameter) Class() string {
+	return p.class
+}
+
+func (p *Parameter) UID() uint32 {
+	return p.obj.UID()
+}
+
+func (p *Parameter) Name() string {
+	return p.name
+}
+
+func (*Parameter) Title() string {
+	return "Named templated parameter type"
+}
+
+// Value returns the value held by the parameter.
+// The returned value is a bool, int32, int64, float32 or float64,
+// depending on the template argument of the TParameter<T>.
+func (p *Parameter) Value() interface{} {
+	return p.val
+}
+
+func (p *Parameter) String() string {
+	return fmt.Sprintf("%s{Name: %s, Value: %v}", p.class, p.name, p.val)
+}
+
+// ROOTUnmarshaler is the interface implemented by an object that can
+// unmarshal itself from a ROOT buffer
+func (p *Parameter) UnmarshalROOT(r *rbytes.RBuffer) error {
+	start := r.Pos()
+	/*vers*/ _, pos, bcnt := r.ReadVersion(p.Class())
+	if err := p.obj.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	p.name = r.ReadString()
+
+	switch p.class {
+	case "TParameter<bool>":
+		p.val = r.ReadBool()
+	case "TParameter<int>":
+		p.val = r.ReadI32()
+	case "TParameter<long>", "TParameter<Long64_t>":
+		p.val = r.ReadI64()
+	case "TParameter<float>":
+		p.val = r.ReadF32()
+	case "TParameter<double>":
+		p.val = r.ReadF64()
+	default:
+		return fmt.Errorf("rbase: invalid TParameter class %q", p.class)
+	}
+
+	r.CheckByteCount(pos, bcnt, start, p.Class())
+	return r.Err()
+}
+
+func (p *Parameter) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+	pos := w.WriteVersion(p.RVersion())
+	if _, err := p.obj.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	w.WriteString(p.name)
+
+	switch v := p.val.(type) {
+	case bool:
+		w.WriteBool(v)
+	case int32:
+		w.WriteI32(v)
+	case int64:
+		w.WriteI64(v)
+	case float32:
+		w.WriteF32(v)
+	case float64:
+		w.WriteF64(v)
+	default:
+		return 0, fmt.Errorf("rbase: invalid TParameter value type %T", v)
+	}
+
+	return w.SetByteCount(pos, p.Class())
+}
+
+func init() {
+	for _, class := range []string{
+		"TParameter<bool>",
+		"TParameter<int>",
+		"TParameter<long>",
+		"TParameter<Long64_t>",
+		"TParameter<float>",
+		"TParameter<double>",
+	} {
+		class := class
+		f := func() reflect.Value {
+			o := &Parameter{class: class}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add(class, f)
+	}
+}
+
+var (
+	_ root.Object        = (*Parameter)(nil)
+	_ root.UIDer         = (*Parameter)(nil)
+	_ root.Named         = (*Parameter)(nil)
+	_ rbytes.Marshaler   = (*Parameter)(nil)
+	_ rbytes.Unmarshaler = (*Parameter)(nil)
+)
@@ -0,0 +1,54 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+)
+
+func TestParameter(t *testing.T) {
+	for _, tc := range []struct {
+		v     interface{}
+		class string
+		str   string
+	}{
+		{v: true, class: "TParameter<bool>", str: "TParameter<bool>{Name: param, Value: true}"},
+		{v: int32(-42), class: "TParameter<int>", str: "TParameter<int>{Name: param, Value: -42}"},
+		{v: int64(1) << 40, class: "TParameter<Long64_t>", str: "TParameter<Long64_t>{Name: param, Value: 1099511627776}"},
+		{v: float32(1.5), class: "TParameter<float>", str: "TParameter<float>{Name: param, Value: 1.5}"},
+		{v: 2.5, class: "TParameter<double>", str: "TParameter<double>{Name: param, Value: 2.5}"},
+	} {
+		t.Run(tc.class, func(t *testing.T) {
+			p := rbase.NewParameter("param", tc.v)
+			if got, want := p.Class(), tc.class; got != want {
+				t.Fatalf("invalid class. got=%q, want=%q", got, want)
+			}
+			if got, want := p.Name(), "param"; got != want {
+				t.Fatalf("invalid name. got=%q, want=%q", got, want)
+			}
+			if got, want := p.Value(), tc.v; got != want {
+				t.Fatalf("invalid value. got=%v (%T), want=%v (%T)", got, got, want, want)
+			}
+			if got, want := p.String(), tc.str; got != want {
+				t.Fatalf("invalid string representation. got=%s, want=%s", got, want)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		defer func() {
+			e := recover()
+			if e == nil {
+				t.Fatalf("expected a panic")
+			}
+			if got, want := e.(error).Error(), "rbase: invalid TParameter value type int"; got != want {
+				t.Fatalf("invalid panic message. got=%q, want=%q", got, want)
+			}
+		}()
+		_ = rbase.NewParameter("param", 42)
+	})
+}
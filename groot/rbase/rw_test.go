@@ -70,6 +70,60 @@ func TestWRBuffer(t *testing.T) {
 				str: "tobjstring-string",
 			},
 		},
+		{
+			name: "TParameter<bool>",
+			want: &Parameter{
+				obj:   Object{ID: 0x0, Bits: 0x3000000},
+				class: "TParameter<bool>",
+				name:  "is-mc",
+				val:   true,
+			},
+		},
+		{
+			name: "TParameter<int>",
+			want: &Parameter{
+				obj:   Object{ID: 0x0, Bits: 0x3000000},
+				class: "TParameter<int>",
+				name:  "run",
+				val:   int32(-42),
+			},
+		},
+		{
+			name: "TParameter<Long64_t>",
+			want: &Parameter{
+				obj:   Object{ID: 0x0, Bits: 0x3000000},
+				class: "TParameter<Long64_t>",
+				name:  "nevts",
+				val:   int64(1) << 40,
+			},
+		},
+		{
+			name: "TParameter<long>",
+			want: &Parameter{
+				obj:   Object{ID: 0x0, Bits: 0x3000000},
+				class: "TParameter<long>",
+				name:  "nevts",
+				val:   int64(-1) << 40,
+			},
+		},
+		{
+			name: "TParameter<float>",
+			want: &Parameter{
+				obj:   Object{ID: 0x0, Bits: 0x3000000},
+				class: "TParameter<float>",
+				name:  "lumi",
+				val:   float32(139.5),
+			},
+		},
+		{
+			name: "TParameter<double>",
+			want: &Parameter{
+				obj:   Object{ID: 0x0, Bits: 0x3000000},
+				class: "TParameter<double>",
+				name:  "xsec",
+				val:   1.234e-5,
+			},
+		},
 		{
 			name: "TProcessID",
 			want: &ProcessID{
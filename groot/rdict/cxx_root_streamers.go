@@ -0,0 +1,51 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdict
+
+import (
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rmeta"
+)
+
+// streamers for the instantiations of C++ ROOT class templates,
+// which are not handled by gen.rboot.
+
+func init() {
+	// TParameter<T>, for the T handled by rbase.Parameter.
+	for _, param := range []struct {
+		typ  string
+		enum rmeta.Enum
+		size int32
+	}{
+		{"bool", rmeta.Bool, 1},
+		{"int", rmeta.Int, 4},
+		{"long", rmeta.Long, 8},
+		{"Long64_t", rmeta.Long64, 8},
+		{"float", rmeta.Float, 4},
+		{"double", rmeta.Double, 8},
+	} {
+		StreamerInfos.Add(NewCxxStreamerInfo("TParameter<"+param.typ+">", 2, 0, []rbytes.StreamerElement{
+			NewStreamerBase(Element{
+				Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+				Type:   rmeta.Base,
+				MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+				EName:  "BASE",
+			}.New(), 1),
+			&StreamerString{StreamerElement: Element{
+				Name:  *rbase.NewNamed("fName", ""),
+				Type:  rmeta.TString,
+				Size:  24,
+				EName: "TString",
+			}.New()},
+			&StreamerBasicType{StreamerElement: Element{
+				Name:  *rbase.NewNamed("fVal", ""),
+				Type:  param.enum,
+				Size:  param.size,
+				EName: param.typ,
+			}.New()},
+		}))
+	}
+}
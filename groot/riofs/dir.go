@@ -712,6 +712,18 @@ func (dir *tdirectoryFile) records(w io.Writer, indent int) error {
 	return nil
 }
 
+// verify checks the consistency of the records of all the keys held by this
+// directory and its sub-directories.
+func (dir *tdirectoryFile) verify() error {
+	for i := range dir.keys {
+		err := dir.keys[i].verify()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func init() {
 	{
 		f := func() reflect.Value {
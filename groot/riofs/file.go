@@ -164,6 +164,33 @@ func NewReader(r Reader) (*File, error) {
 	return f, nil
 }
 
+// NewReaderAt creates a new ROOT file reader, reading the size bytes
+// of the ROOT file from r.
+//
+// NewReaderAt allows to read ROOT files from sources other than the
+// local filesystem, such as in-memory buffers or object stores.
+// If r implements io.Closer, closing the returned file closes r.
+func NewReaderAt(r io.ReaderAt, size int64) (*File, error) {
+	sr := sectionReader{SectionReader: io.NewSectionReader(r, 0, size)}
+	if c, ok := r.(io.Closer); ok {
+		sr.c = c
+	}
+	return NewReader(sr)
+}
+
+// sectionReader adapts an io.SectionReader to the Reader interface.
+type sectionReader struct {
+	*io.SectionReader
+	c io.Closer // underlying closer, if any
+}
+
+func (r sectionReader) Close() error {
+	if r.c == nil {
+		return nil
+	}
+	return r.c.Close()
+}
+
 // Create creates the named ROOT file for writing.
 func Create(name string, opts ...FileOption) (*File, error) {
 	fd, err := os.Create(name)
@@ -969,6 +996,20 @@ func (f *File) Records(w io.Writer) error {
 	return f.dir.records(w, 0)
 }
 
+// Verify checks the integrity of the records of the ROOT file.
+//
+// Verify walks all the keys of the file, recursively, and checks that the
+// byte counts and compressed lengths of their records are consistent with
+// the data stored on file.
+// The returned error reports the name and offset of the first key with
+// an invalid record.
+func (f *File) Verify() error {
+	if fi, err := f.Stat(); err == nil && fi.Size() < f.end {
+		return fmt.Errorf("riofs: file %q is truncated (size=%d, end=%d)", f.id, fi.Size(), f.end)
+	}
+	return f.dir.verify()
+}
+
 var (
 	_ root.Object                = (*File)(nil)
 	_ root.Named                 = (*File)(nil)
@@ -301,6 +301,46 @@ func TestFileOpenStreamerInfo(t *testing.T) {
 	}
 }
 
+func TestFileParameters(t *testing.T) {
+	f, err := groot.Open("../testdata/params.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, tc := range []struct {
+		name  string
+		class string
+		want  interface{}
+	}{
+		{"bool", "TParameter<bool>", true},
+		{"int", "TParameter<int>", int32(-42)},
+		{"i64", "TParameter<Long64_t>", int64(1) << 40},
+		{"float", "TParameter<float>", float32(1.5)},
+		{"lumi", "TParameter<double>", 139.0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj, err := f.Get(tc.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, ok := obj.(*rbase.Parameter)
+			if !ok {
+				t.Fatalf("invalid type: got=%T, want=*rbase.Parameter", obj)
+			}
+			if got, want := p.Class(), tc.class; got != want {
+				t.Fatalf("invalid class: got=%q, want=%q", got, want)
+			}
+			if got, want := p.Name(), tc.name; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := p.Value(), tc.want; got != want {
+				t.Fatalf("invalid value: got=%v (%T), want=%v (%T)", got, got, want, want)
+			}
+		})
+	}
+}
+
 func TestOpenEmptyFile(t *testing.T) {
 	f, err := groot.Open("../testdata/uproot/issue70.root")
 	if err != nil {
@@ -313,6 +353,45 @@ func TestOpenEmptyFile(t *testing.T) {
 	}
 }
 
+func TestNewReaderAt(t *testing.T) {
+	raw, err := ioutil.ReadFile("../testdata/simple.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := riofs.NewReaderAt(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatalf("could not open ROOT file from reader: %v", err)
+	}
+	defer f.Close()
+
+	if got, want := f.Name(), "simple.root"; got != want {
+		t.Fatalf("invalid file name: got=%q, want=%q", got, want)
+	}
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %v", err)
+	}
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		t.Fatalf("invalid object type: got=%T, want=rtree.Tree", obj)
+	}
+	if got, want := tree.Entries(), int64(4); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+
+	_, err = riofs.NewReaderAt(bytes.NewReader(raw), 10)
+	if err == nil {
+		t.Fatalf("expected an error reading a truncated file")
+	}
+}
+
 func TestCreate(t *testing.T) {
 
 	rootls := "rootls"
@@ -393,6 +472,16 @@ func TestCreate(t *testing.T) {
 				&rcont.ArrayD{Data: []float64{1, 2, 3, 4, 5, 6}},
 			},
 		},
+		{
+			name: "TParameter",
+			want: []rtests.ROOTer{
+				rbase.NewParameter("p0", true),
+				rbase.NewParameter("p1", int32(-42)),
+				rbase.NewParameter("p2", int64(1)<<40),
+				rbase.NewParameter("p3", float32(1.5)),
+				rbase.NewParameter("p4", 2.5),
+			},
+		},
 	} {
 		fname := filepath.Join(dir, fmt.Sprintf("out-%d.root", i))
 		t.Run(tc.name, func(t *testing.T) {
@@ -536,3 +625,131 @@ func TestReadOnlyFile(t *testing.T) {
 		t.Fatalf("expected an error. got nil")
 	}
 }
+
+func TestFileVerify(t *testing.T) {
+	for _, fname := range []string{
+		"../testdata/dirs-6.14.00.root",
+		"../testdata/small-flat-tree.root",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			f, err := groot.Open(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			err = f.Verify()
+			if err != nil {
+				t.Fatalf("could not verify file: %+v", err)
+			}
+		})
+	}
+}
+
+func TestFileVerifyCorrupted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riofs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "verify.root")
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := w.Mkdir("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sub.Put("obj", rbase.NewObjString(strings.Repeat("Hello World from Go-HEP! ", 100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	err = r.Verify()
+	if err != nil {
+		t.Fatalf("could not verify file: %+v", err)
+	}
+
+	o, err := r.Get("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := o.(riofs.Directory).Keys()[0]
+	if key.ObjLen() == key.Nbytes()-key.KeyLen() {
+		t.Fatalf("key %q is not compressed", key.Name())
+	}
+
+	raw, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		corrupt func(buf []byte)
+		want    string
+	}{
+		{
+			name: "nbytes",
+			corrupt: func(buf []byte) {
+				buf[key.SeekKey()+3]++ // lowest byte of big-endian nbytes
+			},
+			want: fmt.Sprintf("riofs: key %q at offset %d: inconsistent record length", "obj", key.SeekKey()),
+		},
+		{
+			name: "compressed-length",
+			corrupt: func(buf []byte) {
+				buf[key.SeekKey()+int64(key.KeyLen())+3]++ // lowest byte of compressed size
+			},
+			want: fmt.Sprintf("riofs: key %q at offset %d: inconsistent compressed length", "obj", key.SeekKey()),
+		},
+		{
+			name: "compression-header",
+			corrupt: func(buf []byte) {
+				copy(buf[key.SeekKey()+int64(key.KeyLen()):], "XX")
+			},
+			want: fmt.Sprintf("riofs: key %q at offset %d: invalid compressed block header", "obj", key.SeekKey()),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := make([]byte, len(raw))
+			copy(buf, raw)
+			tc.corrupt(buf)
+
+			fname := filepath.Join(dir, tc.name+".root")
+			err := ioutil.WriteFile(fname, buf, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			err = f.Verify()
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got := err.Error(); !strings.HasPrefix(got, tc.want) {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// +build ignore
+
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	"go-hep.org/x/hep/groot/internal/rtests"
+)
+
+var (
+	root = flag.String("f", "params.root", "output ROOT file")
+)
+
+func main() {
+	flag.Parse()
+
+	out, err := rtests.RunCxxROOT("genparams", []byte(script), *root)
+	if err != nil {
+		log.Fatalf("could not run ROOT macro:\noutput:\n%v\nerror: %+v", string(out), err)
+	}
+}
+
+const script = `
+void genparams(const char* fname) {
+	auto f = TFile::Open(fname, "RECREATE");
+
+	TParameter<bool>("bool", true).Write();
+	TParameter<int>("int", -42).Write();
+	TParameter<Long64_t>("i64", Long64_t(1)<<40).Write();
+	TParameter<float>("float", 1.5).Write();
+	TParameter<double>("lumi", 139.0).Write();
+
+	f->Close();
+
+	exit(0);
+}
+`
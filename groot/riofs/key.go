@@ -611,6 +611,89 @@ func (k *Key) records(w io.Writer, indent int) error {
 	return nil
 }
 
+// verify checks the consistency of the key header stored on file and of
+// the sizes of its (possibly compressed) payload.
+func (k *Key) verify() error {
+	if k.keylen <= 0 || k.nbytes < k.keylen {
+		return k.errorf("invalid record length (nbytes=%d, keylen=%d)", k.nbytes, k.keylen)
+	}
+	end := k.seekkey + int64(k.nbytes)
+	if k.seekkey < k.f.begin || end > k.f.end {
+		return k.errorf("record [%d, %d) outside of file (end=%d)", k.seekkey, end, k.f.end)
+	}
+
+	buf := make([]byte, k.keylen)
+	_, err := k.f.ReadAt(buf, k.seekkey)
+	if err != nil {
+		return k.errorf("could not read key header: %w", err)
+	}
+	var hdr Key
+	err = hdr.UnmarshalROOT(rbytes.NewRBuffer(buf, nil, 0, nil))
+	if err != nil {
+		return k.errorf("could not decode key header: %w", err)
+	}
+	switch {
+	case hdr.nbytes != k.nbytes:
+		return k.errorf("inconsistent record length (got=%d, want=%d)", hdr.nbytes, k.nbytes)
+	case hdr.keylen != k.keylen:
+		return k.errorf("inconsistent key length (got=%d, want=%d)", hdr.keylen, k.keylen)
+	case hdr.objlen != k.objlen:
+		return k.errorf("inconsistent object length (got=%d, want=%d)", hdr.objlen, k.objlen)
+	case hdr.seekkey != k.seekkey:
+		return k.errorf("inconsistent key offset (got=%d)", hdr.seekkey)
+	case hdr.name != k.name:
+		return k.errorf("inconsistent key name (got=%q)", hdr.name)
+	}
+
+	if k.isCompressed() {
+		var (
+			beg  = k.seekkey + int64(k.keylen)
+			size = int64(0)
+			blk  = make([]byte, rcompress.HeaderSize)
+		)
+		for ulen := 0; ulen < int(k.objlen); {
+			_, err = k.f.ReadAt(blk, beg+size)
+			if err != nil {
+				return k.errorf("could not read compressed block header: %w", err)
+			}
+			kind, srcsz, tgtsz := rcompress.BlockSizes(blk)
+			if kind == rcompress.UndefinedCompression || tgtsz == 0 {
+				return k.errorf("invalid compressed block header at offset %d", beg+size)
+			}
+			size += int64(rcompress.HeaderSize + srcsz)
+			ulen += tgtsz
+			if ulen > int(k.objlen) {
+				return k.errorf("inconsistent uncompressed length (got>=%d, want=%d)", ulen, k.objlen)
+			}
+		}
+		if want := int64(k.nbytes - k.keylen); size != want {
+			return k.errorf("inconsistent compressed length (got=%d, want=%d)", size, want)
+		}
+	}
+
+	_, err = k.load(nil)
+	if err != nil {
+		return k.errorf("could not load payload: %w", err)
+	}
+
+	switch k.class {
+	case "TDirectory", "TDirectoryFile":
+		obj, err := k.Object()
+		if err != nil {
+			return k.errorf("could not load directory: %w", err)
+		}
+		return obj.(*tdirectoryFile).verify()
+	}
+	return nil
+}
+
+// errorf returns an error about the key, identified by its name and its
+// offset on file.
+func (k *Key) errorf(format string, args ...interface{}) error {
+	args = append([]interface{}{k.name, k.seekkey}, args...)
+	return fmt.Errorf("riofs: key %q at offset %d: "+format, args...)
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := &Key{}
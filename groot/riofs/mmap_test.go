@@ -0,0 +1,119 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestOpenMmap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riofs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "objstring.root")
+
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		kname = "my-key"
+		want  = rbase.NewObjString("Hello World from Go-HEP!")
+	)
+
+	err = w.Put(kname, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("error closing file: %v", err)
+	}
+
+	f, err := riofs.OpenMmap(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file with mmap: %+v", err)
+	}
+	defer f.Close()
+
+	if got, want := len(f.Keys()), 1; got != want {
+		t.Fatalf("invalid number of keys. got=%d, want=%d", got, want)
+	}
+
+	got, err := f.Get(kname)
+	if err != nil {
+		t.Fatalf("could not read key %q: %+v", kname, err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid value for key %q: got=%v, want=%v", kname, got, want)
+	}
+
+	fi, err := os.Stat(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 16)
+	_, err = f.ReadAt(buf, fi.Size()-8)
+	if err != io.EOF {
+		t.Fatalf("invalid error reading past EOF: got=%v, want=%v", err, io.EOF)
+	}
+	_, err = f.ReadAt(buf, fi.Size()+8)
+	if err != io.EOF {
+		t.Fatalf("invalid error reading after EOF: got=%v, want=%v", err, io.EOF)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close mmap file: %+v", err)
+	}
+}
+
+func TestOpenMmapInvalid(t *testing.T) {
+	_, err := riofs.OpenMmap("../testdata/not-there.root")
+	if err == nil {
+		t.Fatalf("expected an error opening a missing file")
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	benchmarkOpen(b, riofs.Open)
+}
+
+func BenchmarkOpenMmap(b *testing.B) {
+	benchmarkOpen(b, riofs.OpenMmap)
+}
+
+func benchmarkOpen(b *testing.B, open func(string) (*riofs.File, error)) {
+	const fname = "../testdata/dirs-6.14.00.root"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f, err := open(fname)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, key := range f.Keys() {
+			_, err := key.Object()
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		f.Close()
+	}
+}
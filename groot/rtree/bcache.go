@@ -0,0 +1,136 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// SetBasketCacheSize sets the maximum size, in bytes, of the cache of
+// decompressed baskets associated with the provided tree.
+//
+// Once a basket has been read and decompressed, it is kept in memory and
+// subsequent reads of entries held by that basket are served from the cache.
+// Least recently used baskets are evicted when the cache is full.
+// A size of zero disables the cache.
+//
+// SetBasketCacheSize returns an error if the tree is not a TTree, a TNtuple
+// or a chain of those.
+func SetBasketCacheSize(t Tree, size int) error {
+	if size < 0 {
+		return fmt.Errorf("rtree: invalid negative basket cache size (%d)", size)
+	}
+
+	switch t := t.(type) {
+	case *ttree:
+		t.bcache = newBasketCache(size)
+	case *tntuple:
+		t.bcache = newBasketCache(size)
+	case *tchain:
+		for _, tree := range t.trees {
+			err := SetBasketCacheSize(tree, size)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("rtree: basket cache not supported for tree type %T", t)
+	}
+	return nil
+}
+
+// basketCacheOf returns the basket cache associated with t, if any.
+func basketCacheOf(t Tree) *basketCache {
+	switch t := t.(type) {
+	case *ttree:
+		return t.bcache
+	case *tntuple:
+		return t.bcache
+	}
+	return nil
+}
+
+// basketCache is a LRU cache of decompressed baskets, indexed by their
+// position in the file.
+type basketCache struct {
+	mu    sync.Mutex
+	max   int                     // maximum size of the cache in bytes
+	size  int                     // current size of the cache in bytes
+	lru   *list.List              // list of cached baskets, most recently used first
+	items map[int64]*list.Element // cached baskets, indexed by seek position
+
+	hits   int64 // number of baskets served from the cache
+	misses int64 // number of baskets not found in the cache
+}
+
+type basketCacheItem struct {
+	seek    int64
+	bkt     Basket
+	buf     []byte
+	offsets []int32
+}
+
+func newBasketCache(max int) *basketCache {
+	if max == 0 {
+		return nil
+	}
+	return &basketCache{
+		max:   max,
+		lru:   list.New(),
+		items: make(map[int64]*list.Element),
+	}
+}
+
+// get returns the cached basket located at seek, if any.
+func (c *basketCache) get(seek int64) (*basketCacheItem, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elmt, ok := c.items[seek]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.lru.MoveToFront(elmt)
+	return elmt.Value.(*basketCacheItem), true
+}
+
+// add stores the provided decompressed basket, evicting least recently
+// used baskets as needed.
+// Baskets larger than the cache are not stored.
+func (c *basketCache) add(seek int64, bkt Basket, buf []byte) {
+	n := len(buf) + 4*len(bkt.offsets)
+	if n > c.max {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, dup := c.items[seek]; dup {
+		return
+	}
+
+	for c.size+n > c.max {
+		elmt := c.lru.Back()
+		item := c.lru.Remove(elmt).(*basketCacheItem)
+		delete(c.items, item.seek)
+		c.size -= len(item.buf) + 4*len(item.offsets)
+	}
+
+	item := &basketCacheItem{
+		seek:    seek,
+		bkt:     bkt,
+		buf:     buf,
+		offsets: append([]int32(nil), bkt.offsets...),
+	}
+	item.bkt.rbuf = nil
+	item.bkt.offsets = nil
+	c.items[seek] = c.lru.PushFront(item)
+	c.size += n
+}
@@ -0,0 +1,225 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestBasketCache(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-bcache-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const nevts = 1000
+
+	type Data struct {
+		I32    int32
+		F64    float64
+		N      int32
+		SliF64 []float64
+	}
+
+	fname := path.Join(tmp, "bcache.root")
+	func() {
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		var data Data
+		wvars := []WriteVar{
+			{Name: "I32", Value: &data.I32},
+			{Name: "F64", Value: &data.F64},
+			{Name: "N", Value: &data.N},
+			{Name: "SliF64", Value: &data.SliF64, Count: "N"},
+		}
+		tree, err := NewWriter(f, "tree", wvars, WithBasketSize(256))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tree.Close()
+
+		for i := 0; i < nevts; i++ {
+			data.I32 = int32(i)
+			data.F64 = float64(i)
+			data.N = int32(i % 5)
+			data.SliF64 = data.SliF64[:0]
+			for j := 0; j < int(data.N); j++ {
+				data.SliF64 = append(data.SliF64, float64(i))
+			}
+			_, err = tree.Write()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err = tree.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	for _, size := range []int{0, 1 << 20} {
+		t.Run(fmt.Sprintf("size=%d", size), func(t *testing.T) {
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			o, err := f.Get("tree")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree := o.(Tree)
+
+			err = SetBasketCacheSize(tree, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cache := basketCacheOf(tree)
+
+			var data Data
+			sc, err := NewScanner(tree, &data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sc.Close()
+
+			// read entries alternating between both ends of the tree,
+			// so every entry needs a different basket than the previous one.
+			read := func() {
+				t.Helper()
+				for i := int64(0); i < nevts/2; i++ {
+					for _, entry := range []int64{i, nevts - 1 - i} {
+						err := sc.SeekEntry(entry)
+						if err != nil {
+							t.Fatal(err)
+						}
+						if !sc.Next() {
+							t.Fatalf("could not advance to entry %d: %+v", entry, sc.Err())
+						}
+						err = sc.Scan()
+						if err != nil {
+							t.Fatalf("could not scan entry %d: %+v", entry, err)
+						}
+
+						want := Data{
+							I32: int32(entry),
+							F64: float64(entry),
+							N:   int32(entry % 5),
+						}
+						want.SliF64 = make([]float64, want.N)
+						for j := range want.SliF64 {
+							want.SliF64[j] = float64(entry)
+						}
+						if !reflect.DeepEqual(data, want) {
+							t.Fatalf("invalid entry %d:\ngot= %+v\nwant=%+v", entry, data, want)
+						}
+					}
+				}
+			}
+
+			read()
+
+			// only the current basket of each branch is kept in memory.
+			for _, b := range tree.Branches() {
+				if got := loadedBaskets(b); got > 1 {
+					t.Fatalf("branch %q: too many baskets kept in memory: got=%d, want<=1", b.Name(), got)
+				}
+			}
+
+			if cache == nil {
+				// no cache: still make sure random access works.
+				read()
+				return
+			}
+
+			misses := cache.misses
+			if misses == 0 {
+				t.Fatalf("expected baskets to be read from file")
+			}
+			if cache.hits == 0 {
+				t.Fatalf("expected baskets to be served from the cache")
+			}
+
+			read()
+
+			if cache.misses != misses {
+				t.Fatalf("baskets re-read from file: got=%d misses, want=%d", cache.misses, misses)
+			}
+		})
+	}
+}
+
+// loadedBaskets returns the number of baskets of the branch holding
+// their decompressed payload.
+func loadedBaskets(b Branch) int {
+	var bkts []Basket
+	switch b := b.(type) {
+	case *tbranch:
+		bkts = b.baskets
+	case *tbranchElement:
+		bkts = b.baskets
+	}
+	n := 0
+	for i := range bkts {
+		if bkts[i].rbuf != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func TestBasketCacheEviction(t *testing.T) {
+	c := newBasketCache(10)
+	for i := 0; i < 4; i++ {
+		c.add(int64(i), Basket{}, make([]byte, 4))
+	}
+
+	if got, want := c.size, 8; got != want {
+		t.Fatalf("invalid cache size: got=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		seek int64
+		want bool
+	}{
+		{0, false},
+		{1, false},
+		{2, true},
+		{3, true},
+	} {
+		if _, ok := c.get(tc.seek); ok != tc.want {
+			t.Fatalf("invalid cache content for basket %d: got=%v, want=%v", tc.seek, ok, tc.want)
+		}
+	}
+
+	// too large to be cached.
+	c.add(42, Basket{}, make([]byte, 11))
+	if _, ok := c.get(42); ok {
+		t.Fatalf("basket larger than the cache should not be cached")
+	}
+
+	if c := newBasketCache(0); c != nil {
+		t.Fatalf("zero-sized cache should be disabled")
+	}
+}
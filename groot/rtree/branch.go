@@ -57,6 +57,7 @@ type tbranch struct {
 	nextbasket  int64   // next entry that will require us to go to the next basket
 	basket      *Basket // pointer to the current basket
 	basketBuf   []byte  // scratch space for the current basket
+	payloadBuf  []byte  // scratch space for the decompressed payload of the current basket, when not cached
 
 	tree Tree            // tree header
 	btop Branch          // top-level parent branch in the tree
@@ -609,6 +610,12 @@ func (b *tbranch) loadBasket(entry int64) error {
 	if ib < 0 {
 		return fmt.Errorf("rtree: no basket for entry %d", entry)
 	}
+	if b.basket != nil && ib != b.readbasket && b.basketBytes[b.readbasket] != 0 {
+		// only the current basket read from file is kept in memory.
+		// it is re-read (or retrieved from the tree basket cache, if any)
+		// when needed again.
+		b.basket.rbuf = nil
+	}
 	b.readentry = entry
 	b.readbasket = ib
 	b.nextbasket = b.basketEntry[ib+1]
@@ -622,8 +629,10 @@ func (b *tbranch) loadBasket(entry int64) error {
 		return nil
 	}
 
-	b.baskets = append(b.baskets, Basket{})
-	b.basket = &b.baskets[len(b.baskets)-1]
+	for len(b.baskets) <= ib {
+		b.baskets = append(b.baskets, Basket{})
+	}
+	b.basket = &b.baskets[ib]
 	return b.setupBasket(b.basket, ib, entry)
 }
 
@@ -645,7 +654,12 @@ func (b *tbranch) findBasketIndex(entry int64) int {
 			return i
 	*/
 
-	for i := b.readbasket; i < len(b.basketEntry); i++ {
+	beg := b.readbasket
+	if entry < b.firstbasket {
+		// seeking backward.
+		beg = 0
+	}
+	for i := beg; i < len(b.basketEntry); i++ {
 		v := b.basketEntry[i]
 		if v > entry && v > 0 {
 			return i - 1
@@ -694,6 +708,23 @@ func (b *tbranch) setupBasket(bk *Basket, ib int, entry int64) error {
 		}
 
 	default:
+		cache := basketCacheOf(b.tree)
+		if cache != nil {
+			if item, ok := cache.get(seek); ok {
+				*bk = item.bkt
+				bk.offsets = item.offsets
+				bk.rbuf = rbytes.NewRBuffer(item.buf, nil, uint32(bk.key.KeyLen()), sictx)
+				b.firstEntry = b.basketEntry[ib]
+				for _, leaf := range b.leaves {
+					err = leaf.readFromBuffer(bk.rbuf)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+
 		_, err = f.ReadAt(buf, seek)
 		if err != nil {
 			return err
@@ -706,7 +737,15 @@ func (b *tbranch) setupBasket(bk *Basket, ib int, entry int64) error {
 		bk.key.SetFile(f)
 		b.firstEntry = b.basketEntry[ib]
 
-		buf = rbytes.ResizeU8(buf, int(bk.key.ObjLen()))
+		// baskets kept in the tree basket cache own their buffer.
+		// otherwise, only the current basket uses the payload scratch buffer.
+		switch {
+		case cache != nil:
+			buf = make([]byte, bk.key.ObjLen())
+		default:
+			b.payloadBuf = rbytes.ResizeU8(b.payloadBuf, int(bk.key.ObjLen()))
+			buf = b.payloadBuf
+		}
 		_, err = bk.key.Load(buf)
 		if err != nil {
 			return err
@@ -734,6 +773,9 @@ func (b *tbranch) setupBasket(bk *Basket, ib int, entry int64) error {
 			}
 		}
 
+		if cache != nil {
+			cache.add(seek, *bk, buf)
+		}
 	}
 
 	return err
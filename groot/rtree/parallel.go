@@ -0,0 +1,150 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ReadBranches reads all the entries of the named branches of the provided
+// tree, concurrently.
+//
+// ReadBranches uses at most nworkers goroutines to read the branches.
+// If nworkers is zero or negative, runtime.NumCPU() goroutines are used.
+// Branches sharing the same leaf-count are read by the same goroutine.
+// The returned map associates the name of each branch to the slice of its
+// values, one per entry.
+//
+// ReadBranches stops and returns the context error when ctx is canceled.
+// Only single-leaf branches are supported.
+func ReadBranches(ctx context.Context, t Tree, names []string, nworkers int) (map[string][]interface{}, error) {
+	if nworkers <= 0 {
+		nworkers = runtime.NumCPU()
+	}
+
+	var (
+		groups = make(map[string][]ReadVar)
+		keys   []string
+	)
+	for _, name := range names {
+		br := t.Branch(name)
+		if br == nil {
+			return nil, fmt.Errorf("rtree: Tree %q has no branch named %q", t.Name(), name)
+		}
+		leaves := br.Leaves()
+		if n := len(leaves); n != 1 {
+			return nil, fmt.Errorf("rtree: branch %q has %d leaves (only single-leaf branches are supported)", name, n)
+		}
+		leaf := leaves[0]
+
+		// branches sharing a leaf-count need to be read together,
+		// as they share the leaf-count branch.
+		key := name
+		if lcnt := leaf.LeafCount(); lcnt != nil {
+			lbr := t.Leaf(lcnt.Name())
+			if lbr == nil {
+				return nil, fmt.Errorf("rtree: Tree %q has no (count) branch named %q", t.Name(), lcnt.Name())
+			}
+			key = lbr.Branch().Name()
+		}
+		if _, dup := groups[key]; !dup {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], ReadVar{Name: name, Value: newValue(leaf)})
+	}
+
+	var (
+		grp, gctx = errgroup.WithContext(ctx)
+		sem       = make(chan struct{}, nworkers)
+		outs      = make([]map[string][]interface{}, len(keys))
+	)
+	for i, key := range keys {
+		i := i
+		rvars := groups[key]
+		grp.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+			defer func() { <-sem }()
+
+			out, err := readVars(gctx, t, rvars)
+			if err != nil {
+				return err
+			}
+			outs[i] = out
+			return nil
+		})
+	}
+
+	err := grp.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string][]interface{}, len(names))
+	for _, out := range outs {
+		for k, v := range out {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+// readVars reads all the entries of the provided read-variables.
+func readVars(ctx context.Context, t Tree, rvars []ReadVar) (map[string][]interface{}, error) {
+	sc, err := NewScannerVars(t, rvars...)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create scanner: %w", err)
+	}
+	defer sc.Close()
+
+	out := make(map[string][]interface{}, len(rvars))
+	for _, rvar := range rvars {
+		out[rvar.Name] = make([]interface{}, 0, t.Entries())
+	}
+
+	for sc.Next() {
+		err = ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		err = sc.Scan()
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not read entry %d: %w", sc.Entry(), err)
+		}
+
+		for _, rvar := range rvars {
+			out[rvar.Name] = append(out[rvar.Name], copyValue(rvar.Value))
+		}
+	}
+
+	err = sc.Err()
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not traverse tree: %w", err)
+	}
+
+	return out, nil
+}
+
+// copyValue returns a copy of the value pointed at by ptr.
+// Slices are copied, so the returned value does not share its backing
+// array with the scanner buffers.
+func copyValue(ptr interface{}) interface{} {
+	rv := reflect.ValueOf(ptr).Elem()
+	if rv.Kind() != reflect.Slice {
+		return rv.Interface()
+	}
+	cpy := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+	reflect.Copy(cpy, rv)
+	return cpy.Interface()
+}
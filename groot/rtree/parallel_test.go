@@ -0,0 +1,166 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+var parallelBranches = []string{
+	"Int32", "Int64", "Float64", "Str",
+	"ArrayFloat32",
+	"N", "SliceInt32", "SliceFloat64",
+}
+
+func TestReadBranches(t *testing.T) {
+	f, err := riofs.Open("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := obj.(Tree)
+
+	var want []ScannerData
+	{
+		var data ScannerData
+		sc, err := NewScanner(tree, &data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for sc.Next() {
+			err := sc.Scan()
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := data
+			v.SliI32 = append([]int32{}, data.SliI32...)
+			v.SliF64 = append([]float64{}, data.SliF64...)
+			want = append(want, v)
+		}
+		err = sc.Err()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sc.Close()
+	}
+
+	for _, nworkers := range []int{0, 1, 2, 8} {
+		t.Run(fmt.Sprintf("workers=%d", nworkers), func(t *testing.T) {
+			got, err := ReadBranches(context.Background(), tree, parallelBranches, nworkers)
+			if err != nil {
+				t.Fatalf("could not read branches: %+v", err)
+			}
+
+			for _, name := range parallelBranches {
+				if n := len(got[name]); n != len(want) {
+					t.Fatalf("invalid number of entries for %q: got=%d, want=%d", name, n, len(want))
+				}
+			}
+
+			for i, data := range want {
+				for _, tc := range []struct {
+					name string
+					want interface{}
+				}{
+					{"Int32", data.I32},
+					{"Int64", data.I64},
+					{"Float64", data.F64},
+					{"Str", data.Str},
+					{"ArrayFloat32", data.ArrF32},
+					{"N", data.N},
+					{"SliceInt32", data.SliI32},
+					{"SliceFloat64", data.SliF64},
+				} {
+					if v := got[tc.name][i]; !reflect.DeepEqual(v, tc.want) {
+						t.Fatalf("entry %d: invalid value for %q: got=%v, want=%v", i, tc.name, v, tc.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReadBranchesErrors(t *testing.T) {
+	f, err := riofs.Open("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := obj.(Tree)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, tc := range []struct {
+		name  string
+		ctx   context.Context
+		names []string
+		want  error
+	}{
+		{
+			name:  "no-branch",
+			ctx:   context.Background(),
+			names: []string{"Int32", "NotThere"},
+			want:  fmt.Errorf(`rtree: Tree "tree" has no branch named "NotThere"`),
+		},
+		{
+			name:  "canceled",
+			ctx:   canceled,
+			names: parallelBranches,
+			want:  context.Canceled,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadBranches(tc.ctx, tree, tc.names, 2)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want.Error(); got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkReadBranches(b *testing.B) {
+	f, err := riofs.Open("../testdata/small-flat-tree.root")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		b.Fatal(err)
+	}
+	tree := obj.(Tree)
+
+	for _, nworkers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", nworkers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := ReadBranches(context.Background(), tree, parallelBranches, nworkers)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
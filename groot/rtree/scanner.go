@@ -198,7 +198,7 @@ func NewTreeScanner(t Tree, ptr interface{}) (*TreeScanner, error) {
 			}
 		}
 		fptr := rv.Field(i).Addr().Interface()
-		err := br.setAddress(fptr)
+		err := bindField(br, f, fptr)
 		if err != nil {
 			return nil, err
 		}
@@ -619,9 +619,9 @@ func NewScanner(t Tree, ptr interface{}) (*Scanner, error) {
 			}
 		}
 		fptr := rv.Field(i).Addr().Interface()
-		err := br.setAddress(fptr)
+		err := bindField(br, f, fptr)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		args = append(args, fptr)
 		mbr = append(mbr, br)
@@ -720,6 +720,25 @@ func (s *Scanner) Scan() error {
 	return s.scan.err
 }
 
+// bindField binds the struct field f, pointed at by ptr, to the branch br.
+// bindField returns an error if the type of the field does not match
+// the type of the branch.
+func bindField(br Branch, f reflect.StructField, ptr interface{}) (err error) {
+	defer func() {
+		e := recover()
+		if e == nil {
+			return
+		}
+		err = fmt.Errorf("rtree: could not bind field %q (type=%v) to branch %q: %v", f.Name, f.Type, br.Name(), e)
+	}()
+
+	err = br.setAddress(ptr)
+	if err != nil {
+		return fmt.Errorf("rtree: could not bind field %q (type=%v) to branch %q: %w", f.Name, f.Type, br.Name(), err)
+	}
+	return nil
+}
+
 func newValue(leaf Leaf) interface{} {
 	etype := leaf.Type()
 	unsigned := leaf.IsUnsigned()
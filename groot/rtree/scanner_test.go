@@ -1322,6 +1322,30 @@ func TestInvalidScannerTypes(t *testing.T) {
 			},
 			want: fmt.Errorf(`rtree: field[1] "notExported" from rtree.Event is not exported`),
 		},
+		{
+			name: "scanner-type-mismatch",
+			scan: func() error {
+				type Event struct {
+					N float64 `groot:"N"`
+				}
+				var v Event
+				_, err := NewScanner(tree, &v)
+				return err
+			},
+			want: fmt.Errorf(`rtree: could not bind field "N" (type=float64) to branch "N": invalid ptr type *float64 (leaf=N|*rtree.LeafI)`),
+		},
+		{
+			name: "tree-scanner-type-mismatch",
+			scan: func() error {
+				type Event struct {
+					N float64 `groot:"N"`
+				}
+				var v Event
+				_, err := NewTreeScanner(tree, &v)
+				return err
+			},
+			want: fmt.Errorf(`rtree: could not bind field "N" (type=float64) to branch "N": invalid ptr type *float64 (leaf=N|*rtree.LeafI)`),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			err := tc.scan()
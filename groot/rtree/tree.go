@@ -69,6 +69,8 @@ type ttree struct {
 	friends     *rcont.List   // pointer to the list of firend elements
 	userInfo    *rcont.List   // pointer to a list of user objects associated with this tree
 	branchRef   root.Object   // branch supporting the reftable (if any) // FIXME(sbinet): impl TBranchRef?
+
+	bcache *basketCache // cache of decompressed baskets (if any)
 }
 
 type clusters struct {
@@ -11,6 +11,14 @@ type Bin2D struct {
 	Dist   Dist2D
 }
 
+func (b Bin2D) clone() Bin2D {
+	return Bin2D{
+		XRange: b.XRange.clone(),
+		YRange: b.YRange.clone(),
+		Dist:   b.Dist.clone(),
+	}
+}
+
 // Rank returns the number of dimensions for this bin.
 func (Bin2D) Rank() int { return 2 }
 
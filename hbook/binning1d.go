@@ -6,6 +6,7 @@ package hbook
 
 import (
 	"errors"
+	"math"
 	"sort"
 )
 
@@ -22,6 +23,7 @@ var (
 	errOverlapXAxis   = errors.New("hbook: invalid X-binning (overlap)")
 	errNotSortedXAxis = errors.New("hbook: X-edges slice not sorted")
 	errDupEdgesXAxis  = errors.New("hbook: duplicates in X-edge values")
+	errNaNEdgesXAxis  = errors.New("hbook: NaN in X-edge values")
 
 	errInvalidYAxis   = errors.New("hbook: invalid Y-axis limits")
 	errEmptyYAxis     = errors.New("hbook: Y-axis with zero bins")
@@ -87,6 +89,11 @@ func newBinning1DFromEdges(edges []float64) Binning1D {
 	if len(edges) <= 1 {
 		panic(errShortXAxis)
 	}
+	for _, v := range edges {
+		if math.IsNaN(v) {
+			panic(errNaNEdgesXAxis)
+		}
+	}
 	if !sort.IsSorted(sort.Float64Slice(edges)) {
 		panic(errNotSortedXAxis)
 	}
@@ -179,10 +186,12 @@ func (bng *Binning1D) scaleW(f float64) {
 	}
 }
 
+// Underflow returns the distribution of the underflow bin.
 func (bng *Binning1D) Underflow() *Dist1D {
 	return &bng.Outflows[0]
 }
 
+// Overflow returns the distribution of the overflow bin.
 func (bng *Binning1D) Overflow() *Dist1D {
 	return &bng.Outflows[1]
 }
@@ -0,0 +1,79 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// linearIndexOf is the reference linear-scan implementation of Bin1Ds.IndexOf.
+func linearIndexOf(bins Bin1Ds, v float64) int {
+	if v < bins[0].Range.Min {
+		return UnderflowBin1D
+	}
+	if v >= bins[len(bins)-1].Range.Max {
+		return OverflowBin1D
+	}
+	for i, bin := range bins {
+		if bin.Range.Min <= v && v < bin.Range.Max {
+			return i
+		}
+	}
+	return len(bins)
+}
+
+func TestBin1DsIndexOf(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1234))
+
+	edges := make([]float64, 1001)
+	for i := 1; i < len(edges); i++ {
+		edges[i] = edges[i-1] + 0.01 + rnd.Float64()
+	}
+
+	// bins with gaps: drop every third bin.
+	var gaps []Range
+	for i := 0; i+1 < len(edges); i++ {
+		if i%3 == 2 {
+			continue
+		}
+		gaps = append(gaps, Range{Min: edges[i], Max: edges[i+1]})
+	}
+
+	for _, tc := range []struct {
+		name string
+		bng  Binning1D
+	}{
+		{"uniform", newBinning1D(1000, -50, 50)},
+		{"edges", newBinning1DFromEdges(edges)},
+		{"gaps", newBinning1DFromBins(gaps)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bins := Bin1Ds(tc.bng.Bins)
+			xmin := bins[0].Range.Min
+			xmax := bins[len(bins)-1].Range.Max
+
+			check := func(v float64) {
+				got := bins.IndexOf(v)
+				want := linearIndexOf(bins, v)
+				if got != want {
+					t.Fatalf("invalid index for %v: got=%d, want=%d", v, got, want)
+				}
+			}
+
+			// values exactly on the edges.
+			for _, bin := range bins {
+				check(bin.Range.Min)
+				check(bin.Range.Max)
+			}
+
+			// random values, including outside of the axis.
+			width := xmax - xmin
+			for i := 0; i < 100000; i++ {
+				check(xmin - 0.1*width + 1.2*width*rnd.Float64())
+			}
+		})
+	}
+}
@@ -133,6 +133,34 @@ func newBinning2DFromEdges(xedges, yedges []float64) Binning2D {
 	return bng
 }
 
+func (bng *Binning2D) clone() Binning2D {
+	o := Binning2D{
+		Bins:   make([]Bin2D, len(bng.Bins)),
+		Dist:   bng.Dist.clone(),
+		XRange: bng.XRange.clone(),
+		YRange: bng.YRange.clone(),
+		Nx:     bng.Nx,
+		Ny:     bng.Ny,
+		XEdges: make([]Bin1D, len(bng.XEdges)),
+		YEdges: make([]Bin1D, len(bng.YEdges)),
+	}
+
+	for i, bin := range bng.Bins {
+		o.Bins[i] = bin.clone()
+	}
+	for i, v := range bng.Outflows {
+		o.Outflows[i] = v.clone()
+	}
+	for i, v := range bng.XEdges {
+		o.XEdges[i] = v.clone()
+	}
+	for i, v := range bng.YEdges {
+		o.YEdges[i] = v.clone()
+	}
+
+	return o
+}
+
 func (bng *Binning2D) entries() int64 {
 	return bng.Dist.Entries()
 }
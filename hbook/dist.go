@@ -4,7 +4,12 @@
 
 package hbook
 
-import "math"
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+)
 
 // Dist0D is a 0-dim distribution.
 type Dist0D struct {
@@ -125,7 +130,8 @@ func (d *Dist1D) relErrW() float64 {
 	return d.Dist.relErrW()
 }
 
-// mean returns the weighted mean of the distribution
+// mean returns the weighted mean of the distribution.
+// Negative weights are supported, as long as the sum of weights is not zero.
 func (d *Dist1D) mean() float64 {
 	// FIXME(sbinet): check for low stats?
 	return d.SumWX() / d.SumW()
@@ -134,13 +140,35 @@ func (d *Dist1D) mean() float64 {
 // variance returns the weighted variance of the distribution, defined as:
 //  sig2 = ( \sum(wx^2) * \sum(w) - \sum(wx)^2 ) / ( \sum(w)^2 - \sum(w^2) )
 // see: https://en.wikipedia.org/wiki/Weighted_arithmetic_mean
+//
+// The variance is NaN when the denominator is not positive, ie: when the
+// number of effective entries is not larger than 1, as can happen with a
+// single entry or with negative weights.
+// A negative numerator coming from floating point rounding is clamped to
+// zero, while a larger negative numerator (only possible with negative
+// weights) also gives a NaN variance.
 func (d *Dist1D) variance() float64 {
-	// FIXME(sbinet): check for low stats?
-	sumw := d.SumW()
-	num := d.SumWX2()*sumw - d.SumWX()*d.SumWX()
-	den := sumw*sumw - d.SumW2()
-	v := num / den
-	return math.Abs(v)
+	// relative tolerance on the cancellation in the numerator.
+	const eps = 1e-12
+
+	var (
+		sumw  = d.SumW()
+		sumwx = d.SumWX()
+		lhs   = d.SumWX2() * sumw
+		rhs   = sumwx * sumwx
+		num   = lhs - rhs
+		den   = sumw*sumw - d.SumW2()
+	)
+	if !(den > 0) {
+		return math.NaN()
+	}
+	if num < 0 {
+		if -num > eps*math.Max(math.Abs(lhs), rhs) {
+			return math.NaN()
+		}
+		num = 0
+	}
+	return num / den
 }
 
 // stdDev returns the weighted standard deviation of the distribution
@@ -181,6 +209,34 @@ func (d *Dist1D) scaleW(f float64) {
 	d.Stats.SumWX2 *= f
 }
 
+// sample replaces the content of the distribution with a random draw
+// around its current content: a Poisson draw for unweighted distributions,
+// and a Gaussian draw using the error on the sum of weights otherwise.
+// The mean and variance in x are preserved.
+func (d *Dist1D) sample(rng *rand.Rand) {
+	if d.Dist.N == 0 {
+		return
+	}
+
+	sumw := d.Dist.SumW
+	if float64(d.Dist.N) == sumw && sumw == d.Dist.SumW2 {
+		n := distuv.Poisson{Lambda: sumw, Src: rng}.Rand()
+		d.Dist.N = int64(n)
+		d.Dist.SumW = n
+		d.Dist.SumW2 = n
+		d.Stats.SumWX *= n / sumw
+		d.Stats.SumWX2 *= n / sumw
+		return
+	}
+
+	w := distuv.Normal{Mu: sumw, Sigma: d.errW(), Src: rng}.Rand()
+	d.Dist.SumW = w
+	if sumw != 0 {
+		d.Stats.SumWX *= w / sumw
+		d.Stats.SumWX2 *= w / sumw
+	}
+}
+
 func (d *Dist1D) scaleX(f float64) {
 	d.Stats.SumWX *= f
 	d.Stats.SumWX2 *= f * f
@@ -195,6 +251,14 @@ type Dist2D struct {
 	}
 }
 
+func (d Dist2D) clone() Dist2D {
+	return Dist2D{
+		X:     d.X.clone(),
+		Y:     d.Y.clone(),
+		Stats: d.Stats,
+	}
+}
+
 // Rank returns the number of dimensions of the distribution.
 func (*Dist2D) Rank() int {
 	return 2
@@ -311,6 +375,19 @@ func (d *Dist2D) fill(x, y, w float64) {
 	d.Stats.SumWXY += w * x * y
 }
 
+func (d *Dist2D) addScaled(a, a2 float64, o Dist2D) {
+	d.X.addScaled(a, a2, o.X)
+	d.Y.addScaled(a, a2, o.Y)
+	d.Stats.SumWXY += a * o.Stats.SumWXY
+}
+
+// swapXY returns a copy of the distribution with the X and Y moments swapped.
+func (d Dist2D) swapXY() Dist2D {
+	o := d
+	o.X, o.Y = d.Y, d.X
+	return o
+}
+
 func (d *Dist2D) scaleW(f float64) {
 	d.X.scaleW(f)
 	d.Y.scaleW(f)
@@ -9,12 +9,14 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"strings"
 
 	"go-hep.org/x/hep/rio"
+	"golang.org/x/exp/rand"
 )
 
 // H1D is a 1-dim histogram with weighted entries.
@@ -36,6 +38,7 @@ func NewH1D(n int, xmin, xmax float64) *H1D {
 // It panics if the length of edges is <= 1.
 // It panics if the edges are not sorted.
 // It panics if there are duplicate edge values.
+// It panics if any of the edges is NaN.
 func NewH1DFromEdges(edges []float64) *H1D {
 	return &H1D{
 		Binning: newBinning1DFromEdges(edges),
@@ -53,6 +56,86 @@ func NewH1DFromBins(bins ...Range) *H1D {
 	}
 }
 
+// NewH1DFrom returns a 1-dim histogram with n bins, filled with the
+// provided values, each with a unit weight.
+// The range of the histogram spans the minimum and maximum of the values,
+// both included.
+// Non-finite values (NaN and ±Inf) are skipped: they neither enter the
+// range computation nor fill the histogram.
+// It panics if values holds no finite value.
+func NewH1DFrom(values []float64, n int) *H1D {
+	h, err := newH1DFromValues(values, n)
+	if err != nil {
+		panic(err)
+	}
+	for _, v := range values {
+		if isFinite(v) {
+			h.Binning.fill(v, 1)
+		}
+	}
+	return h
+}
+
+// NewH1DFromWeighted returns a 1-dim histogram with n bins, filled with
+// the provided values and their associated weights.
+// The range of the histogram spans the minimum and maximum of the values,
+// both included.
+// Non-finite values (NaN and ±Inf) are skipped, together with their weights.
+// It returns an error if values and weights do not have the same length,
+// or if values holds no finite value.
+func NewH1DFromWeighted(values, weights []float64, n int) (*H1D, error) {
+	if len(values) != len(weights) {
+		return nil, fmt.Errorf("hbook: lengths mismatch (values=%d, weights=%d)", len(values), len(weights))
+	}
+	h, err := newH1DFromValues(values, n)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		if isFinite(v) {
+			h.Binning.fill(v, weights[i])
+		}
+	}
+	return h, nil
+}
+
+// newH1DFromValues returns an empty 1-dim histogram with n bins,
+// whose range spans the minimum and maximum of the finite values.
+func newH1DFromValues(values []float64, n int) (*H1D, error) {
+	var (
+		min = math.Inf(+1)
+		max = math.Inf(-1)
+	)
+	for _, v := range values {
+		if !isFinite(v) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	switch {
+	case len(values) == 0:
+		return nil, fmt.Errorf("hbook: no values to histogram")
+	case min > max:
+		return nil, fmt.Errorf("hbook: no finite values to histogram")
+	}
+	if min == max {
+		min -= 0.5
+		max += 0.5
+	}
+	h := NewH1D(n, min, max)
+
+	// make sure the maximum falls into the last bin.
+	xmax := math.Nextafter(max, math.Inf(+1))
+	h.Binning.XRange.Max = xmax
+	h.Binning.Bins[n-1].Range.Max = xmax
+	return h, nil
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
 // Clone returns a deep copy of this 1-dim histogram.
 func (h *H1D) Clone() *H1D {
 	return &H1D{
@@ -89,7 +172,10 @@ func (h *H1D) Entries() int64 {
 	return h.Binning.entries()
 }
 
-// EffEntries returns the number of effective entries in this histogram
+// EffEntries returns the number of effective entries in this histogram,
+// (ΣW)²/ΣW², the statistically meaningful number of entries of a
+// weighted histogram.
+// Overflows are included in the computation.
 func (h *H1D) EffEntries() float64 {
 	return h.Binning.effEntries()
 }
@@ -118,18 +204,25 @@ func (h *H1D) SumWX2() float64 {
 
 // XMean returns the mean X.
 // Overflows are included in the computation.
+// Negative weights are supported, as long as the sum of weights is not zero.
 func (h *H1D) XMean() float64 {
 	return h.Binning.Dist.mean()
 }
 
 // XVariance returns the variance in X.
 // Overflows are included in the computation.
+//
+// XVariance returns NaN when the number of effective entries is not
+// larger than 1, or when negative weights lead to a negative variance.
+// Tiny negative variances coming from floating point rounding are clamped
+// to zero.
 func (h *H1D) XVariance() float64 {
 	return h.Binning.Dist.variance()
 }
 
 // XStdDev returns the standard deviation in X.
 // Overflows are included in the computation.
+// See XVariance for how negative weights are handled.
 func (h *H1D) XStdDev() float64 {
 	return h.Binning.Dist.stdDev()
 }
@@ -142,6 +235,7 @@ func (h *H1D) XStdErr() float64 {
 
 // XRMS returns the XRMS in X.
 // Overflows are included in the computation.
+// XRMS returns NaN when negative weights make the weighted mean of x² negative.
 func (h *H1D) XRMS() float64 {
 	return h.Binning.Dist.rms()
 }
@@ -180,6 +274,26 @@ func (h *H1D) Bin(x float64) *Bin1D {
 	return &h.Binning.Bins[idx]
 }
 
+// Underflow returns the sum of weights of the underflow bin.
+func (h *H1D) Underflow() float64 {
+	return h.Binning.Underflow().SumW()
+}
+
+// UnderflowW2 returns the sum of squared weights of the underflow bin.
+func (h *H1D) UnderflowW2() float64 {
+	return h.Binning.Underflow().SumW2()
+}
+
+// Overflow returns the sum of weights of the overflow bin.
+func (h *H1D) Overflow() float64 {
+	return h.Binning.Overflow().SumW()
+}
+
+// OverflowW2 returns the sum of squared weights of the overflow bin.
+func (h *H1D) OverflowW2() float64 {
+	return h.Binning.Overflow().SumW2()
+}
+
 // XMin returns the low edge of the X-axis of this histogram.
 func (h *H1D) XMin() float64 {
 	return h.Binning.xMin()
@@ -191,6 +305,10 @@ func (h *H1D) XMax() float64 {
 }
 
 // Scale scales the content of each bin by the given factor.
+//
+// The moments of the distribution (mean, variance, RMS, ...) are preserved.
+// Calling Scale periodically with a factor in ]0,1[ implements an exponential
+// decay of the histogram: subsequent entries dominate older ones.
 func (h *H1D) Scale(factor float64) {
 	h.Binning.scaleW(factor)
 }
@@ -198,25 +316,32 @@ func (h *H1D) Scale(factor float64) {
 // Integral computes the integral of the histogram.
 //
 // The number of parameters can be 0 or 2.
-// If 0, overflows are included.
+// If 0, the integral is the total sum of weights of the histogram:
+// the in-range bins, the underflow bin and the overflow bin are all included.
+// This is the integral to use to normalize a histogram to the total number
+// of (weighted) entries, as done by Scale(1/h.Integral()).
 // If 2, the first parameter must be the lower bound of the range in which
 // the integral is computed and the second one the upper range.
 //
 // If the lower bound is math.Inf(-1) then the underflow bin is included.
 // If the upper bound is math.Inf(+1) then the overflow bin is included.
+// Otherwise, the integral only includes in-range bins.
 //
 // Examples:
 //
-//    // integral of all in-range bins + overflows
+//    // integral of all in-range bins, underflow and overflow bins included.
 //    v := h.Integral()
 //
-//    // integral of all in-range bins, underflow and overflow bins included.
+//    // same as above.
 //    v := h.Integral(math.Inf(-1), math.Inf(+1))
 //
-//    // integrall of all in-range bins, overflow bin included
+//    // integral of all in-range bins only.
+//    v := h.Integral(h.XMin(), h.XMax())
+//
+//    // integral of all in-range bins, overflow bin included
 //    v := h.Integral(h.Binning.XRange.Min, math.Inf(+1))
 //
-//    // integrall of all bins for which the lower edge is in [0.5, 5.5)
+//    // integral of all bins for which the lower edge is in [0.5, 5.5)
 //    v := h.Integral(0.5, 5.5)
 func (h *H1D) Integral(args ...float64) float64 {
 	min, max := 0., 0.
@@ -249,6 +374,357 @@ func (h *H1D) Integral(args ...float64) float64 {
 	return integral
 }
 
+// IntegralRange computes the integral of the histogram between xmin and xmax.
+//
+// Bins fully contained in [xmin, xmax] contribute their whole content.
+// Bins only partially covered by the range contribute the fraction of
+// their content corresponding to the covered fraction of their width.
+// Under- and over-flows are not taken into account.
+//
+// IntegralRange panics if xmin > xmax.
+func (h *H1D) IntegralRange(xmin, xmax float64) float64 {
+	sumw, _ := h.IntegralRangeW2(xmin, xmax)
+	return sumw
+}
+
+// IntegralRangeW2 computes the integral of the histogram between xmin and xmax,
+// as well as the corresponding integral of the squared weights, so the
+// uncertainty on the integral can be computed as math.Sqrt(sumw2).
+//
+// See IntegralRange for how partially covered bins are handled.
+// The sum of squared weights of a bin covered by a fraction f of
+// its width is scaled by f², as its content is scaled by f.
+func (h *H1D) IntegralRangeW2(xmin, xmax float64) (sumw, sumw2 float64) {
+	if xmin > xmax {
+		panic("hbook: min > max")
+	}
+
+	for i := range h.Binning.Bins {
+		bin := &h.Binning.Bins[i]
+		lo := math.Max(xmin, bin.XMin())
+		hi := math.Min(xmax, bin.XMax())
+		if hi <= lo {
+			continue
+		}
+		frac := (hi - lo) / bin.XWidth()
+		sumw += frac * bin.SumW()
+		sumw2 += frac * frac * bin.SumW2()
+	}
+	return sumw, sumw2
+}
+
+// Cumulative returns a new histogram with the same binning, where each bin
+// holds the sum of the contents of all the bins up to (and including) it.
+// If reverse is true, each bin holds the sum of the contents of all the bins
+// from (and including) it up to the last bin.
+//
+// Sums of squared weights are accumulated along the way, so errors are
+// added in quadrature.
+// Under- and over-flows are not folded into the first and last bins:
+// they are copied over, as is the total distribution.
+func (h *H1D) Cumulative(reverse bool) *H1D {
+	o := h.Clone()
+	bins := o.Binning.Bins
+	switch {
+	case reverse:
+		for i := len(bins) - 2; i >= 0; i-- {
+			bins[i].addScaled(1, 1, bins[i+1])
+		}
+	default:
+		for i := 1; i < len(bins); i++ {
+			bins[i].addScaled(1, 1, bins[i-1])
+		}
+	}
+	return o
+}
+
+// Sample returns a new histogram, with the same binning, where the content
+// of each bin and of the under- and over-flows is replaced by a random draw
+// around its current content.
+//
+// Unweighted bins are replaced by a Poisson draw, with the bin content as
+// mean, and weighted bins by a Gaussian draw, with the bin content as mean
+// and the bin error as standard deviation.
+// The mean x value of each bin is preserved.
+// Entries falling in gaps between bins are dropped from the new histogram.
+func (h *H1D) Sample(rng *rand.Rand) *H1D {
+	o := h.Clone()
+	bng := &o.Binning
+	bng.Dist = Dist1D{}
+	for i := range bng.Outflows {
+		bng.Outflows[i].sample(rng)
+		bng.Dist.addScaled(1, 1, bng.Outflows[i])
+	}
+	for i := range bng.Bins {
+		bin := &bng.Bins[i]
+		bin.Dist.sample(rng)
+		bng.Dist.addScaled(1, 1, bin.Dist)
+	}
+	return o
+}
+
+// Chi2Test returns the chi2 and number of degrees of freedom of the
+// homogeneity test between h and o, with bin errors taken into account:
+//
+//  chi2 = \sum_i (W_h w_{o,i} - W_o w_{h,i})^2 / (W_h^2 s2_{o,i} + W_o^2 s2_{h,i})
+//
+// where w_i and s2_i are the sum of weights and the sum of squared weights
+// in bin i, and W the sum of weights of the in-range bins.
+// Bins empty in both histograms are skipped.
+// The number of degrees of freedom is the number of bins used, minus one.
+//
+// Under- and over-flows are not taken into account.
+// Chi2Test returns an error if the binnings of h and o are not identical
+// or if one of the histograms is empty.
+func (h *H1D) Chi2Test(o *H1D) (chi2 float64, ndf int, err error) {
+	err = h.checkBinning(o)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		hsum = h.inRangeSumW()
+		osum = o.inRangeSumW()
+	)
+	if hsum == 0 || osum == 0 {
+		return 0, 0, fmt.Errorf("hbook: chi2 test with an empty histogram")
+	}
+
+	n := 0
+	for i := range h.Binning.Bins {
+		var (
+			hbin = &h.Binning.Bins[i]
+			obin = &o.Binning.Bins[i]
+			den  = hsum*hsum*obin.SumW2() + osum*osum*hbin.SumW2()
+		)
+		if den == 0 {
+			continue
+		}
+		num := hsum*obin.SumW() - osum*hbin.SumW()
+		chi2 += num * num / den
+		n++
+	}
+
+	return chi2, n - 1, nil
+}
+
+// KolmogorovTest returns the probability of the Kolmogorov-Smirnov test
+// between h and o, computed from the maximum distance between their
+// normalized cumulative distributions.
+// The effective number of entries of the histograms is used to take
+// bin errors into account.
+//
+// Under- and over-flows are not taken into account.
+// KolmogorovTest returns an error if the binnings of h and o are not
+// identical or if one of the histograms is empty.
+func (h *H1D) KolmogorovTest(o *H1D) (prob float64, err error) {
+	err = h.checkBinning(o)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		hsum, hsum2 float64
+		osum, osum2 float64
+	)
+	for i := range h.Binning.Bins {
+		hbin := &h.Binning.Bins[i]
+		obin := &o.Binning.Bins[i]
+		hsum += hbin.SumW()
+		hsum2 += hbin.SumW2()
+		osum += obin.SumW()
+		osum2 += obin.SumW2()
+	}
+	if hsum == 0 || osum == 0 {
+		return 0, fmt.Errorf("hbook: Kolmogorov test with an empty histogram")
+	}
+
+	var (
+		dmax float64
+		hcdf float64
+		ocdf float64
+	)
+	for i := range h.Binning.Bins {
+		hcdf += h.Binning.Bins[i].SumW() / hsum
+		ocdf += o.Binning.Bins[i].SumW() / osum
+		dmax = math.Max(dmax, math.Abs(hcdf-ocdf))
+	}
+
+	var (
+		hn = hsum * hsum / hsum2
+		on = osum * osum / osum2
+		z  = dmax * math.Sqrt(hn*on/(hn+on))
+	)
+	return kolmogorovProb(z), nil
+}
+
+// checkBinning returns an error if the binnings of h and o are not identical.
+func (h *H1D) checkBinning(o *H1D) error {
+	if h.Len() != o.Len() {
+		return fmt.Errorf("hbook: histograms have different number of bins (%d != %d)", h.Len(), o.Len())
+	}
+	for i := range h.Binning.Bins {
+		hb := h.Binning.Bins[i].Range
+		ob := o.Binning.Bins[i].Range
+		if hb != ob {
+			return fmt.Errorf(
+				"hbook: histograms have different bin #%d ([%v, %v) != [%v, %v))",
+				i, hb.Min, hb.Max, ob.Min, ob.Max,
+			)
+		}
+	}
+	return nil
+}
+
+// inRangeSumW returns the sum of weights of the in-range bins.
+func (h *H1D) inRangeSumW() float64 {
+	sum := 0.0
+	for i := range h.Binning.Bins {
+		sum += h.Binning.Bins[i].SumW()
+	}
+	return sum
+}
+
+// kolmogorovProb returns the probability for the Kolmogorov distribution
+// to be larger than z, as in ROOT's TMath::KolmogorovProb.
+func kolmogorovProb(z float64) float64 {
+	const (
+		w  = 2.50662827
+		c1 = -1.2337005501361697 // -pi^2/8
+		c2 = 9 * c1
+		c3 = 25 * c1
+	)
+
+	u := math.Abs(z)
+	switch {
+	case u < 0.2:
+		return 1
+	case u < 0.755:
+		v := 1 / (u * u)
+		return 1 - w*(math.Exp(c1*v)+math.Exp(c2*v)+math.Exp(c3*v))/u
+	case u < 6.8116:
+		var (
+			fj   = [4]float64{-2, -8, -18, -32}
+			r    [4]float64
+			v    = u * u
+			maxj = int(math.Max(1, math.Round(3/u)))
+		)
+		for j := 0; j < maxj; j++ {
+			r[j] = math.Exp(fj[j] * v)
+		}
+		return 2 * (r[0] - r[1] + r[2] - r[3])
+	default:
+		return 0
+	}
+}
+
+// Quantile returns the x value at which the cumulative distribution of
+// the in-range bins reaches the fraction p of their total sum of weights.
+// The x value is linearly interpolated within the bin containing it.
+//
+// Under- and over-flows are not taken into account.
+// Quantile panics if p is not in [0,1].
+// Quantile returns NaN if the sum of weights of the in-range bins is zero.
+func (h *H1D) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(fmt.Errorf("hbook: quantile fraction %v not in [0,1]", p))
+	}
+
+	bins := h.Binning.Bins
+	sumw := 0.0
+	for i := range bins {
+		sumw += bins[i].SumW()
+	}
+	if sumw == 0 {
+		return math.NaN()
+	}
+
+	var (
+		target = p * sumw
+		cumul  = 0.0
+	)
+	for i := range bins {
+		bin := &bins[i]
+		w := bin.SumW()
+		if w > 0 && cumul+w >= target {
+			frac := (target - cumul) / w
+			return bin.XMin() + frac*bin.XWidth()
+		}
+		cumul += w
+	}
+	return bins[len(bins)-1].XMax()
+}
+
+// Median returns the median of the in-range bins of this histogram.
+// It is equivalent to h.Quantile(0.5).
+func (h *H1D) Median() float64 {
+	return h.Quantile(0.5)
+}
+
+// MaxBin returns the index, the center and the content of the in-range
+// bin with the largest content.
+// Ties are resolved in favor of the bin with the lowest index.
+func (h *H1D) MaxBin() (idx int, center, content float64) {
+	bins := h.Binning.Bins
+	for i := range bins {
+		if w := bins[i].SumW(); i == 0 || w > content {
+			idx = i
+			content = w
+		}
+	}
+	return idx, bins[idx].XMid(), content
+}
+
+// FWHM returns the full width at half maximum of the highest bin of the
+// histogram.
+// The positions where the contents cross half the maximum, on each side
+// of the highest bin, are linearly interpolated between bin centers.
+//
+// FWHM returns an error if the highest bin is the first or last in-range
+// bin, if its content is not positive, or if the contents do not fall
+// down to half the maximum on both sides.
+func (h *H1D) FWHM() (float64, error) {
+	var (
+		idx, _, peak = h.MaxBin()
+		bins         = h.Binning.Bins
+		half         = 0.5 * peak
+	)
+	if peak <= 0 {
+		return 0, fmt.Errorf("hbook: no positive maximum to compute FWHM (max=%v)", peak)
+	}
+	if idx == 0 || idx == len(bins)-1 {
+		return 0, fmt.Errorf("hbook: maximum at the edge of the histogram (bin=%d) to compute FWHM", idx)
+	}
+
+	lo := idx - 1
+	for lo >= 0 && bins[lo].SumW() > half {
+		lo--
+	}
+	if lo < 0 {
+		return 0, errors.New("hbook: could not find the left half maximum crossing to compute FWHM")
+	}
+
+	hi := idx + 1
+	for hi < len(bins) && bins[hi].SumW() > half {
+		hi++
+	}
+	if hi == len(bins) {
+		return 0, errors.New("hbook: could not find the right half maximum crossing to compute FWHM")
+	}
+
+	// crossing returns the x value where the line between the centers
+	// of the bins b1 and b2 crosses y.
+	crossing := func(b1, b2 *Bin1D, y float64) float64 {
+		x1, y1 := b1.XMid(), b1.SumW()
+		x2, y2 := b2.XMid(), b2.SumW()
+		return x1 + (y-y1)*(x2-x1)/(y2-y1)
+	}
+
+	xlo := crossing(&bins[lo], &bins[lo+1], half)
+	xhi := crossing(&bins[hi-1], &bins[hi], half)
+	return xhi - xlo, nil
+}
+
 // Value returns the content of the idx-th bin.
 //
 // Value implements gonum/plot/plotter.Valuer
@@ -261,6 +737,15 @@ func (h *H1D) Error(i int) float64 {
 	return math.Sqrt(h.Binning.Bins[i].SumW2())
 }
 
+// Errors returns the errors, defined as sqrt(sumW2), of all the in-range bins.
+func (h *H1D) Errors() []float64 {
+	errs := make([]float64, len(h.Binning.Bins))
+	for i := range h.Binning.Bins {
+		errs[i] = h.Binning.Bins[i].ErrW()
+	}
+	return errs
+}
+
 // Len returns the number of bins for this histogram
 //
 // Len implements gonum/plot/plotter.Valuer
@@ -292,6 +777,38 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return xmin, xmax, ymin, ymax
 }
 
+// sparkLevels are the characters used to draw the bins contents,
+// from the lowest to the highest.
+const sparkLevels = " .:-=+*#%@"
+
+// String returns a short multi-line summary of the histogram:
+// number of entries, mean, RMS, integral of the in-range bins,
+// under- and overflows, and a sparkline of the bins contents
+// scaled to the largest bin.
+func (h *H1D) String() string {
+	o := new(strings.Builder)
+	fmt.Fprintf(o, "H1D %q: bins=%d, range=[%g, %g]\n", h.Name(), h.Len(), h.XMin(), h.XMax())
+	fmt.Fprintf(o, "entries=%d, mean=%.4g, rms=%.4g\n", h.Entries(), h.XMean(), h.XRMS())
+	fmt.Fprintf(o, "integral=%g, underflow=%g, overflow=%g\n", h.inRangeSumW(), h.Underflow(), h.Overflow())
+
+	max := 0.0
+	for i := range h.Binning.Bins {
+		max = math.Max(max, h.Binning.Bins[i].SumW())
+	}
+
+	o.WriteString("|")
+	for i := range h.Binning.Bins {
+		v := h.Binning.Bins[i].SumW()
+		lvl := 0
+		if max > 0 && v > 0 {
+			lvl = 1 + int(v/max*float64(len(sparkLevels)-2))
+		}
+		o.WriteByte(sparkLevels[lvl])
+	}
+	o.WriteString("|")
+	return o.String()
+}
+
 // RioMarshal implements rio.RioMarshaler
 func (h *H1D) RioMarshal(w io.Writer) error {
 	data, err := h.MarshalBinary()
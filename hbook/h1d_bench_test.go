@@ -116,3 +116,50 @@ func BenchmarkNH1DFillFlat(b *testing.B) {
 		st_process_evts(100, hists, st_process_evts_flat)
 	}
 }
+
+func BenchmarkH1DFill10kBins(b *testing.B) {
+	edges := make([]float64, 10001)
+	for i := range edges {
+		edges[i] = float64(i) * 0.01
+	}
+	for _, bc := range []struct {
+		name string
+		h1   *H1D
+	}{
+		{"uniform", NewH1D(10000, 0, 100)},
+		{"edges", NewH1DFromEdges(edges)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			h1 := bc.h1
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h1.Fill(rnd()*100., 1.)
+			}
+		})
+	}
+}
+
+func BenchmarkH1DFillN(b *testing.B) {
+	xs := make([]float64, 1000)
+	for i := range xs {
+		xs[i] = rnd() * 100.
+	}
+
+	b.Run("loop", func(b *testing.B) {
+		h1 := NewH1D(100, 0., 100.)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for _, x := range xs {
+				h1.Fill(x, 1.)
+			}
+		}
+	})
+
+	b.Run("fill-n", func(b *testing.B) {
+		h1 := NewH1D(100, 0., 100.)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			h1.FillN(xs, nil)
+		}
+	})
+}
@@ -7,6 +7,7 @@ package hbook
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -14,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/plot/plotter"
 )
@@ -334,6 +336,8 @@ func TestH1DEdgesWithPanics(t *testing.T) {
 		{0, 1, 0, 1},
 		{0, 1, 2, 2},
 		{0, 1, 2, 2, 2},
+		{0, math.NaN(), 2},
+		{math.NaN(), 1, 2},
 	} {
 		panicked, _ := panics(func() {
 			_ = NewH1DFromEdges(test)
@@ -378,6 +382,9 @@ func TestH1DIntegral(t *testing.T) {
 	if got, want := h1.Integral(h1.XMin(), h1.XMax()), 5.3; got != want {
 		t.Errorf("H1D.Integral(xmin,xmax) = %v. want=%v\n", got, want)
 	}
+	if got, want := h1.Integral(h1.XMin(), h1.XMax())+h1.Underflow()+h1.Overflow(), integral; math.Abs(got-want) > 1e-12 {
+		t.Errorf("H1D.Integral(xmin,xmax)+flows = %v. want=%v\n", got, want)
+	}
 
 	integralall := h1.Integral(math.Inf(-1), math.Inf(+1))
 	if integralall != 8.7 {
@@ -430,6 +437,608 @@ func TestH1DIntegral(t *testing.T) {
 	}
 }
 
+func TestH1DScaleDecay(t *testing.T) {
+	const decay = 0.5
+
+	h := NewH1D(10, 0, 10)
+	for i := 0; i < 4; i++ {
+		h.Fill(1.5, 1)
+	}
+	h.Scale(decay)
+	for i := 0; i < 4; i++ {
+		h.Fill(8.5, 1)
+	}
+
+	if got, want := h.SumW(), 4*decay+4; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h.SumW2(), 4*decay*decay+4; got != want {
+		t.Fatalf("invalid sumw2: got=%v, want=%v", got, want)
+	}
+
+	// the moments are those of the weighted entries.
+	ref := NewH1D(10, 0, 10)
+	for i := 0; i < 4; i++ {
+		ref.Fill(1.5, decay)
+		ref.Fill(8.5, 1)
+	}
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"mean", h.XMean(), ref.XMean()},
+		{"variance", h.XVariance(), ref.XVariance()},
+		{"rms", h.XRMS(), ref.XRMS()},
+		{"stderr", h.XStdErr(), ref.XStdErr()},
+	} {
+		if math.Abs(tc.got-tc.want) > 1e-12 {
+			t.Errorf("invalid %s: got=%v, want=%v", tc.name, tc.got, tc.want)
+		}
+	}
+	if h.XMean() <= 5 {
+		t.Fatalf("recent entries should dominate: mean=%v", h.XMean())
+	}
+}
+
+func TestH1DIntegralRange(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	h.Fill(-1, 10)
+	h.Fill(0.5, 1)
+	h.Fill(1.5, 2)
+	h.Fill(2.5, 3)
+	h.Fill(3.5, 4)
+	h.Fill(+5, 10)
+
+	for _, tc := range []struct {
+		xmin, xmax  float64
+		sumw, sumw2 float64
+	}{
+		{0, 4, 10, 30},
+		{-10, 10, 10, 30},
+		{1, 3, 5, 13},
+		{0.5, 2, 2.5, 4.25},
+		{1.25, 1.75, 1, 1},
+		{2.5, 3.5, 3.5, 6.25},
+		{2, 2, 0, 0},
+		{-10, -5, 0, 0},
+		{5, 10, 0, 0},
+	} {
+		t.Run(fmt.Sprintf("[%v,%v]", tc.xmin, tc.xmax), func(t *testing.T) {
+			sumw, sumw2 := h.IntegralRangeW2(tc.xmin, tc.xmax)
+			if !floats.EqualWithinAbs(sumw, tc.sumw, 1e-12) {
+				t.Fatalf("invalid sumw: got=%v, want=%v", sumw, tc.sumw)
+			}
+			if !floats.EqualWithinAbs(sumw2, tc.sumw2, 1e-12) {
+				t.Fatalf("invalid sumw2: got=%v, want=%v", sumw2, tc.sumw2)
+			}
+			if got := h.IntegralRange(tc.xmin, tc.xmax); got != sumw {
+				t.Fatalf("invalid integral: got=%v, want=%v", got, sumw)
+			}
+		})
+	}
+
+	panicked, _ := panics(func() { h.IntegralRange(2, 1) })
+	if !panicked {
+		t.Fatalf("expected a panic")
+	}
+}
+
+func TestH1DOutflows(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	h.Fill(-1, 2)
+	h.Fill(-10, 3)
+	h.Fill(0, 1)
+	h.Fill(3.9, 1)
+	h.Fill(4, 0.5)
+	h.Fill(40, 1.5)
+
+	for _, tc := range []struct {
+		name string
+		f    func() float64
+		want float64
+	}{
+		{"underflow", h.Underflow, 5},
+		{"underflow-w2", h.UnderflowW2, 13},
+		{"overflow", h.Overflow, 2},
+		{"overflow-w2", h.OverflowW2, 2.5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.f(); got != tc.want {
+				t.Fatalf("got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+
+	if got, want := h.Integral(h.XMin(), h.XMax()), 2.0; got != want {
+		t.Fatalf("invalid in-range integral: got=%v, want=%v", got, want)
+	}
+}
+
+func TestH1DCumulative(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	h.Fill(-1, 10)
+	h.Fill(0.5, 1)
+	h.Fill(1.5, 2)
+	h.Fill(2.5, 3)
+	h.Fill(2.5, 1)
+	h.Fill(5, 20)
+
+	for _, tc := range []struct {
+		reverse     bool
+		sumw, sumw2 []float64
+	}{
+		{
+			reverse: false,
+			sumw:    []float64{1, 3, 7, 7},
+			sumw2:   []float64{1, 5, 15, 15},
+		},
+		{
+			reverse: true,
+			sumw:    []float64{7, 6, 4, 0},
+			sumw2:   []float64{15, 14, 10, 0},
+		},
+	} {
+		t.Run(fmt.Sprintf("reverse=%v", tc.reverse), func(t *testing.T) {
+			c := h.Cumulative(tc.reverse)
+			if got, want := c.Len(), h.Len(); got != want {
+				t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+			}
+			for i := range c.Binning.Bins {
+				bin := &c.Binning.Bins[i]
+				if got, want := bin.SumW(), tc.sumw[i]; got != want {
+					t.Fatalf("bin %d: invalid sumw: got=%v, want=%v", i, got, want)
+				}
+				if got, want := bin.SumW2(), tc.sumw2[i]; got != want {
+					t.Fatalf("bin %d: invalid sumw2: got=%v, want=%v", i, got, want)
+				}
+				if got, want := bin.Range, h.Binning.Bins[i].Range; got != want {
+					t.Fatalf("bin %d: invalid range: got=%v, want=%v", i, got, want)
+				}
+			}
+			if got, want := c.Binning.Outflows, h.Binning.Outflows; got != want {
+				t.Fatalf("invalid outflows:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+
+	// original histogram should be left untouched.
+	if got, want := h.Value(0), 1.0; got != want {
+		t.Fatalf("histogram was modified: got=%v, want=%v", got, want)
+	}
+}
+
+func TestH1DSample(t *testing.T) {
+	const nsamples = 2000
+
+	for _, tc := range []struct {
+		name string
+		w    float64
+	}{
+		{name: "unweighted", w: 1},
+		{name: "weighted", w: 0.5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewH1D(4, 0, 4)
+			for i, n := range []int{5, 10, 20, 40, 0, 8} {
+				x := float64(i) - 0.5 // underflow, 4 bins, overflow
+				for j := 0; j < n; j++ {
+					h.Fill(x, tc.w)
+				}
+			}
+
+			rng := rand.New(rand.NewSource(1234))
+			var (
+				dists = append([]Dist1D{h.Binning.Outflows[0]}, h.Binning.Outflows[1])
+				sums  = make([]float64, h.Len()+2)
+			)
+			for i := range h.Binning.Bins {
+				dists = append(dists, h.Binning.Bins[i].Dist)
+			}
+
+			for i := 0; i < nsamples; i++ {
+				o := h.Sample(rng)
+				if got, want := o.Len(), h.Len(); got != want {
+					t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+				}
+				sample := append([]Dist1D{o.Binning.Outflows[0]}, o.Binning.Outflows[1])
+				tot := 0.0
+				for j := range o.Binning.Bins {
+					bin := &o.Binning.Bins[j]
+					if got, want := bin.Range, h.Binning.Bins[j].Range; got != want {
+						t.Fatalf("bin %d: invalid range: got=%v, want=%v", j, got, want)
+					}
+					sample = append(sample, bin.Dist)
+				}
+				for j, d := range sample {
+					ref := dists[j]
+					switch tc.w {
+					case 1:
+						if got, want := d.SumW(), float64(d.Entries()); got != want {
+							t.Fatalf("dist %d: invalid unweighted content: sumw=%v, n=%v", j, got, want)
+						}
+					default:
+						if got, want := d.SumW2(), ref.SumW2(); got != want {
+							t.Fatalf("dist %d: invalid sumw2: got=%v, want=%v", j, got, want)
+						}
+					}
+					if d.SumW() != 0 {
+						if got, want := d.mean(), ref.mean(); math.Abs(got-want) > 1e-12 {
+							t.Fatalf("dist %d: invalid mean: got=%v, want=%v", j, got, want)
+						}
+					}
+					sums[j] += d.SumW()
+					tot += d.SumW()
+				}
+				if got, want := o.SumW(), tot; math.Abs(got-want) > 1e-12 {
+					t.Fatalf("invalid total sumw: got=%v, want=%v", got, want)
+				}
+			}
+
+			for j, ref := range dists {
+				var (
+					mean = sums[j] / nsamples
+					want = ref.SumW()
+					err  = 5 * ref.errW() / math.Sqrt(nsamples)
+				)
+				if math.Abs(mean-want) > err {
+					t.Fatalf("dist %d: invalid mean content: got=%v, want=%v+/-%v", j, mean, want, err)
+				}
+			}
+
+			// original histogram should be left untouched.
+			if got, want := h.Value(2), 40*tc.w; got != want {
+				t.Fatalf("histogram was modified: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestH1DChi2Test(t *testing.T) {
+	newH1D := func(ws ...float64) *H1D {
+		h := NewH1D(len(ws), 0, float64(len(ws)))
+		for i, w := range ws {
+			if w != 0 {
+				h.Fill(float64(i)+0.5, w)
+			}
+		}
+		return h
+	}
+
+	for _, tc := range []struct {
+		name   string
+		h1, h2 *H1D
+		chi2   float64
+		ndf    int
+		err    error
+	}{
+		{
+			name: "proportional",
+			h1:   newH1D(1, 2, 3),
+			h2:   newH1D(2, 4, 6),
+			chi2: 0,
+			ndf:  2,
+		},
+		{
+			name: "disjoint",
+			h1:   newH1D(1, 0),
+			h2:   newH1D(0, 1),
+			chi2: 2,
+			ndf:  1,
+		},
+		{
+			name: "skip-empty-bins",
+			h1:   newH1D(1, 0, 0),
+			h2:   newH1D(0, 1, 0),
+			chi2: 2,
+			ndf:  1,
+		},
+		{
+			name: "nbins-mismatch",
+			h1:   newH1D(1, 2, 3),
+			h2:   newH1D(1, 2),
+			err:  fmt.Errorf("hbook: histograms have different number of bins (3 != 2)"),
+		},
+		{
+			name: "range-mismatch",
+			h1:   newH1D(1, 2),
+			h2:   NewH1D(2, 0, 4),
+			err:  fmt.Errorf("hbook: histograms have different bin #0 ([0, 1) != [0, 2))"),
+		},
+		{
+			name: "empty",
+			h1:   newH1D(1, 2),
+			h2:   newH1D(0, 0),
+			err:  fmt.Errorf("hbook: chi2 test with an empty histogram"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			chi2, ndf, err := tc.h1.Chi2Test(tc.h2)
+			switch {
+			case err != nil && tc.err != nil:
+				if got, want := err.Error(), tc.err.Error(); got != want {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not run chi2 test: %+v", err)
+			case tc.err != nil:
+				t.Fatalf("expected an error (%v)", tc.err)
+			}
+			if got, want := chi2, tc.chi2; math.Abs(got-want) > 1e-12 {
+				t.Fatalf("invalid chi2: got=%v, want=%v", got, want)
+			}
+			if got, want := ndf, tc.ndf; got != want {
+				t.Fatalf("invalid ndf: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestH1DKolmogorovTest(t *testing.T) {
+	fill := func(seed uint64, mean float64, w float64) *H1D {
+		h := NewH1D(20, -4, 4)
+		rnd := rand.New(rand.NewSource(seed))
+		for i := 0; i < 10000; i++ {
+			h.Fill(rnd.NormFloat64()+mean, w)
+		}
+		return h
+	}
+
+	var (
+		ref  = fill(1, 0, 1)
+		same = fill(2, 0, 2)
+		diff = fill(3, 0.2, 1)
+	)
+
+	prob, err := ref.KolmogorovTest(ref)
+	if err != nil {
+		t.Fatalf("could not run KS test: %+v", err)
+	}
+	if got, want := prob, 1.0; got != want {
+		t.Fatalf("invalid KS probability for identical histograms: got=%v, want=%v", got, want)
+	}
+
+	prob, err = ref.KolmogorovTest(same)
+	if err != nil {
+		t.Fatalf("could not run KS test: %+v", err)
+	}
+	if prob < 0.05 {
+		t.Fatalf("invalid KS probability for compatible histograms: %v", prob)
+	}
+
+	chi2, ndf, err := ref.Chi2Test(same)
+	if err != nil {
+		t.Fatalf("could not run chi2 test: %+v", err)
+	}
+	if chi2/float64(ndf) > 2 {
+		t.Fatalf("invalid chi2/ndf for compatible histograms: %v/%d", chi2, ndf)
+	}
+
+	prob, err = ref.KolmogorovTest(diff)
+	if err != nil {
+		t.Fatalf("could not run KS test: %+v", err)
+	}
+	if prob > 1e-6 {
+		t.Fatalf("invalid KS probability for incompatible histograms: %v", prob)
+	}
+
+	chi2, ndf, err = ref.Chi2Test(diff)
+	if err != nil {
+		t.Fatalf("could not run chi2 test: %+v", err)
+	}
+	if chi2/float64(ndf) < 5 {
+		t.Fatalf("invalid chi2/ndf for incompatible histograms: %v/%d", chi2, ndf)
+	}
+
+	_, err = ref.KolmogorovTest(NewH1D(10, -4, 4))
+	if err == nil {
+		t.Fatalf("expected an error for a binning mismatch")
+	}
+
+	_, err = ref.KolmogorovTest(NewH1D(20, -4, 4))
+	if err == nil {
+		t.Fatalf("expected an error for an empty histogram")
+	}
+
+	for _, tc := range []struct {
+		z, want float64
+	}{
+		{0.1, 1},
+		{0.5, 0.9639452436648751},
+		{1, 0.26999967167735456},
+		{2, 0.0006709252557796953},
+		{7, 0},
+	} {
+		if got := kolmogorovProb(tc.z); math.Abs(got-tc.want) > 1e-6 {
+			t.Errorf("invalid Kolmogorov probability for z=%v: got=%v, want=%v", tc.z, got, tc.want)
+		}
+	}
+}
+
+func TestH1DQuantile(t *testing.T) {
+	h := NewH1D(10, 0, 10)
+	for i := 0; i < 10; i++ {
+		h.Fill(float64(i)+0.5, 2)
+	}
+	// outflows should not affect quantiles.
+	h.Fill(-1, 10)
+	h.Fill(20, 5)
+
+	for _, tc := range []struct {
+		p    float64
+		want float64
+	}{
+		{0, 0},
+		{0.05, 0.5},
+		{0.25, 2.5},
+		{0.5, 5},
+		{0.73, 7.3},
+		{1, 10},
+	} {
+		t.Run(fmt.Sprintf("p=%v", tc.p), func(t *testing.T) {
+			got := h.Quantile(tc.p)
+			if !floats.EqualWithinAbs(got, tc.want, 1e-12) {
+				t.Fatalf("invalid quantile: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+
+	if got, want := h.Median(), 5.0; got != want {
+		t.Fatalf("invalid median: got=%v, want=%v", got, want)
+	}
+
+	for _, p := range []float64{-0.1, 1.1} {
+		panicked, _ := panics(func() { h.Quantile(p) })
+		if !panicked {
+			t.Fatalf("expected a panic for p=%v", p)
+		}
+	}
+
+	if got := NewH1D(10, 0, 10).Median(); !math.IsNaN(got) {
+		t.Fatalf("invalid median for empty histogram: got=%v, want=NaN", got)
+	}
+}
+
+func TestH1DMaxBinFWHM(t *testing.T) {
+	newH1D := func(ws ...float64) *H1D {
+		h := NewH1D(len(ws), 0, float64(len(ws)))
+		for i, w := range ws {
+			h.Fill(float64(i)+0.5, w)
+		}
+		return h
+	}
+
+	for _, tc := range []struct {
+		name    string
+		h       *H1D
+		idx     int
+		center  float64
+		content float64
+		fwhm    float64
+		err     bool
+	}{
+		{
+			name:    "exact",
+			h:       newH1D(0, 1, 2, 4, 8, 4, 2, 1, 0, 0),
+			idx:     4,
+			center:  4.5,
+			content: 8,
+			fwhm:    2,
+		},
+		{
+			name:    "interpolated",
+			h:       newH1D(0, 1, 2, 6, 8, 5, 2, 1, 0, 0),
+			idx:     4,
+			center:  4.5,
+			content: 8,
+			fwhm:    2.5 + 1.0/3,
+		},
+		{
+			name:    "ties",
+			h:       newH1D(0, 8, 8, 0),
+			idx:     1,
+			center:  1.5,
+			content: 8,
+			fwhm:    2,
+		},
+		{
+			name:    "edge",
+			h:       newH1D(8, 4, 2, 1),
+			idx:     0,
+			center:  0.5,
+			content: 8,
+			err:     true,
+		},
+		{
+			name:    "no-left-crossing",
+			h:       newH1D(6, 8, 2, 1),
+			idx:     1,
+			center:  1.5,
+			content: 8,
+			err:     true,
+		},
+		{
+			name:    "no-right-crossing",
+			h:       newH1D(1, 2, 8, 5),
+			idx:     2,
+			center:  2.5,
+			content: 8,
+			err:     true,
+		},
+		{
+			name:   "empty",
+			h:      NewH1D(10, 0, 10),
+			idx:    0,
+			center: 0.5,
+			err:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			idx, center, content := tc.h.MaxBin()
+			if idx != tc.idx || center != tc.center || content != tc.content {
+				t.Fatalf("invalid max bin: got=(%d, %v, %v), want=(%d, %v, %v)",
+					idx, center, content, tc.idx, tc.center, tc.content,
+				)
+			}
+
+			fwhm, err := tc.h.FWHM()
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("could not compute FWHM: %+v", err)
+			case err == nil && tc.err:
+				t.Fatalf("expected an error (fwhm=%v)", fwhm)
+			case err == nil:
+				if !floats.EqualWithinAbs(fwhm, tc.fwhm, 1e-12) {
+					t.Fatalf("invalid FWHM: got=%v, want=%v", fwhm, tc.fwhm)
+				}
+			}
+		})
+	}
+
+	// gaussian with sigma=1: FWHM = 2*sqrt(2*ln(2)) sigma.
+	h := NewH1D(200, -5, 5)
+	for i := range h.Binning.Bins {
+		x := h.Binning.Bins[i].XMid()
+		h.Fill(x, math.Exp(-0.5*x*x))
+	}
+	fwhm, err := h.FWHM()
+	if err != nil {
+		t.Fatalf("could not compute FWHM: %+v", err)
+	}
+	if want := 2 * math.Sqrt(2*math.Ln2); !floats.EqualWithinAbs(fwhm, want, 1e-3) {
+		t.Fatalf("invalid gaussian FWHM: got=%v, want=%v", fwhm, want)
+	}
+}
+
+func TestH1DWeightedErrors(t *testing.T) {
+	h := NewH1D(3, 0, 3)
+	h.Fill(0.5, 2)
+	h.Fill(0.5, 3)
+	h.Fill(1.5, 0.5)
+	h.Fill(1.5, 0.5)
+	h.Fill(1.5, 0.5)
+	h.Fill(-1, 4)
+
+	want := []float64{math.Sqrt(13), math.Sqrt(0.75), 0}
+	if got := h.Errors(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid errors:\ngot= %v\nwant=%v", got, want)
+	}
+	for i, v := range want {
+		if got := h.Error(i); got != v {
+			t.Fatalf("invalid error for bin %d: got=%v, want=%v", i, got, v)
+		}
+		if got := h.Binning.Bins[i].ErrW(); got != v {
+			t.Fatalf("invalid bin error for bin %d: got=%v, want=%v", i, got, v)
+		}
+	}
+	if got, want := h.SumW2(), 13+0.75+16; got != want {
+		t.Fatalf("invalid sumw2: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Binning.Underflow().SumW2(), 16.0; got != want {
+		t.Fatalf("invalid underflow sumw2: got=%v, want=%v", got, want)
+	}
+}
+
 func TestH1DNegativeWeights(t *testing.T) {
 	h1 := NewH1D(5, 0, 100)
 	h1.Fill(10, -200)
@@ -453,6 +1062,72 @@ func TestH1DNegativeWeights(t *testing.T) {
 		t.Errorf("std-dev differ:\nh1=%v\nh2=%v\n", x1, x2)
 	}
 	*/
+
+	var (
+		xs = []float64{1, 2, 3, 4, 5}
+		ws = []float64{2, 3, -1, 4, -0.5}
+		h  = NewH1D(5, 0, 6)
+	)
+
+	var sumw, sumw2, sumwx, sumwx2 float64
+	for i, x := range xs {
+		h.Fill(x, ws[i])
+		sumw += ws[i]
+		sumw2 += ws[i] * ws[i]
+		sumwx += ws[i] * x
+		sumwx2 += ws[i] * x * x
+	}
+
+	mean := sumwx / sumw
+	var dev2 float64
+	for i, x := range xs {
+		dev2 += ws[i] * (x - mean) * (x - mean)
+	}
+	variance := dev2 / (sumw - sumw2/sumw)
+
+	for _, tc := range []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"mean", h.XMean(), mean},
+		{"variance", h.XVariance(), variance},
+		{"stddev", h.XStdDev(), math.Sqrt(variance)},
+		{"rms", h.XRMS(), math.Sqrt(sumwx2 / sumw)},
+	} {
+		if !floats.EqualWithinAbsOrRel(tc.got, tc.want, tol, tol) {
+			t.Errorf("invalid %s: got=%v, want=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	// rounding errors on a vanishing variance are clamped to zero.
+	h = NewH1D(5, 0, 6)
+	for _, w := range []float64{1.5, 1.5, -0.7} {
+		h.Fill(3.7, w)
+	}
+	if got := h.XVariance(); got != 0 {
+		t.Errorf("invalid variance for a single x value: got=%v, want=0", got)
+	}
+	if got := h.XStdDev(); got != 0 {
+		t.Errorf("invalid stddev for a single x value: got=%v, want=0", got)
+	}
+
+	// less than one effective entry.
+	h = NewH1D(5, 0, 6)
+	h.Fill(1, 2)
+	h.Fill(2, -1)
+	if got := h.XVariance(); !math.IsNaN(got) {
+		t.Errorf("invalid variance for less than one effective entry: got=%v, want=NaN", got)
+	}
+
+	// negative weights dominating the spread.
+	h = NewH1D(5, 0, 6)
+	h.Fill(1, 3)
+	h.Fill(2, 3)
+	h.Fill(3, -1)
+	if got := h.XVariance(); !math.IsNaN(got) {
+		t.Errorf("invalid negative variance: got=%v, want=NaN", got)
+	}
 }
 
 func TestH1DSerialization(t *testing.T) {
@@ -504,6 +1179,38 @@ func TestH1DSerialization(t *testing.T) {
 		}
 	}()
 
+	// test json.Marshaler/Unmarshaler
+	func() {
+		href := href.Clone()
+		href.Fill(-1, 0.1)
+		href.Fill(200, 1.0/3)
+
+		raw, err := json.Marshal(href)
+		if err != nil {
+			t.Fatalf("could not serialize histogram: %v", err)
+		}
+
+		var hnew H1D
+		err = json.Unmarshal(raw, &hnew)
+		if err != nil {
+			t.Fatalf("could not deserialize histogram: %v", err)
+		}
+
+		if !reflect.DeepEqual(href, &hnew) {
+			t.Fatalf("ref=%v\nnew=%v\n", href, &hnew)
+		}
+		if got, want := hnew.XMean(), href.XMean(); got != want {
+			t.Fatalf("invalid mean: got=%v, want=%v", got, want)
+		}
+		if got, want := hnew.XVariance(), href.XVariance(); got != want {
+			t.Fatalf("invalid variance: got=%v, want=%v", got, want)
+		}
+
+		err = json.Unmarshal([]byte(`{"type":"H2D"}`), &hnew)
+		if err == nil {
+			t.Fatalf("expected an error decoding an H2D into an H1D")
+		}
+	}()
 }
 
 func TestH1DWriteYODA(t *testing.T) {
@@ -680,6 +1387,105 @@ func TestH1DFillN(t *testing.T) {
 	}()
 }
 
+func TestNewH1DFrom(t *testing.T) {
+	xs := []float64{2, 1, 3, 5, 2.5}
+
+	h := NewH1DFrom(xs, 4)
+	if got, want := [2]float64{h.XMin(), h.Binning.Bins[0].XMax()}, [2]float64{1, 2}; got != want {
+		t.Fatalf("invalid range: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Entries(), int64(len(xs)); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := h.Underflow()+h.Overflow(), 0.0; got != want {
+		t.Fatalf("invalid outflows: got=%v, want=%v", got, want)
+	}
+	for i, want := range []float64{1, 2, 1, 1} {
+		if got := h.Value(i); got != want {
+			t.Fatalf("bin %d: invalid content: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	h, err := NewH1DFromWeighted(xs, []float64{1, 2, 3, 4, 5}, 4)
+	if err != nil {
+		t.Fatalf("could not create weighted histogram: %+v", err)
+	}
+	for i, want := range []float64{2, 1 + 5, 3, 4} {
+		if got := h.Value(i); got != want {
+			t.Fatalf("bin %d: invalid weighted content: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	h = NewH1DFrom([]float64{3, 3}, 2)
+	if got, want := [2]float64{h.XMin(), h.Binning.Bins[0].XMax()}, [2]float64{2.5, 3}; got != want {
+		t.Fatalf("invalid range for constant values: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Value(1), 2.0; got != want {
+		t.Fatalf("invalid content for constant values: got=%v, want=%v", got, want)
+	}
+
+	_, err = NewH1DFromWeighted(xs, []float64{1}, 4)
+	if err == nil {
+		t.Fatalf("expected an error for mismatched lengths")
+	}
+	_, err = NewH1DFromWeighted(nil, nil, 4)
+	if err == nil {
+		t.Fatalf("expected an error for empty values")
+	}
+
+	func() {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Fatalf("expected a panic for empty values")
+			}
+		}()
+		NewH1DFrom(nil, 4)
+	}()
+}
+
+func TestNewH1DFromNonFinite(t *testing.T) {
+	xs := []float64{2, math.NaN(), 1, math.Inf(+1), 3, 5, math.Inf(-1), 2.5}
+
+	h := NewH1DFrom(xs, 4)
+	if got, want := [2]float64{h.XMin(), h.Binning.Bins[0].XMax()}, [2]float64{1, 2}; got != want {
+		t.Fatalf("invalid range: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Entries(), int64(5); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := h.Underflow()+h.Overflow(), 0.0; got != want {
+		t.Fatalf("invalid outflows: got=%v, want=%v", got, want)
+	}
+	if got := h.XMean(); math.IsNaN(got) || math.IsInf(got, 0) {
+		t.Fatalf("invalid mean: got=%v", got)
+	}
+
+	h, err := NewH1DFromWeighted(xs, []float64{1, 10, 2, 10, 3, 4, 10, 5}, 4)
+	if err != nil {
+		t.Fatalf("could not create weighted histogram: %+v", err)
+	}
+	for i, want := range []float64{2, 1 + 5, 3, 4} {
+		if got := h.Value(i); got != want {
+			t.Fatalf("bin %d: invalid weighted content: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	nans := []float64{math.NaN(), math.Inf(+1)}
+	_, err = NewH1DFromWeighted(nans, []float64{1, 1}, 4)
+	if err == nil {
+		t.Fatalf("expected an error for non-finite values")
+	}
+
+	func() {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Fatalf("expected a panic for non-finite values")
+			}
+		}()
+		NewH1DFrom(nans, 4)
+	}()
+}
+
 func TestH1DClone(t *testing.T) {
 	h1 := NewH1D(10, 0, 10)
 	h1.FillN(
@@ -737,3 +1543,60 @@ func TestH1DClone(t *testing.T) {
 		)
 	}
 }
+
+func TestH1DString(t *testing.T) {
+	h := NewH1D(8, 0, 8)
+	h.Annotation()["name"] = "h1"
+	for _, v := range []struct{ x, w float64 }{
+		{-1, 1},
+		{0.5, 1},
+		{2.5, 4},
+		{3.5, 8},
+		{4.5, 2},
+		{7.5, 1},
+		{9, 2},
+	} {
+		h.Fill(v.x, v.w)
+	}
+
+	got := h.String()
+	want := `H1D "h1": bins=8, range=[0, 8]
+entries=7, mean=3.789, rms=4.49
+integral=16, underflow=1, overflow=2
+|: +@-  :|`
+	if got != want {
+		t.Fatalf("invalid summary:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got, want := NewH1D(3, 0, 1).String(), "H1D \"\": bins=3, range=[0, 1]\nentries=0, mean=NaN, rms=NaN\nintegral=0, underflow=0, overflow=0\n|   |"; got != want {
+		t.Fatalf("invalid summary of empty histogram:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestH1DEffEntries(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	for _, v := range []struct{ x, w float64 }{
+		{0.5, 1},
+		{1.5, 2},
+		{2.5, 3},
+		{9.0, 4}, // overflow
+	} {
+		h.Fill(v.x, v.w)
+	}
+
+	// (1+2+3+4)^2 / (1+4+9+16)
+	if got, want := h.EffEntries(), 100.0/30.0; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid effective entries: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Entries(), int64(4); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+
+	h = NewH1D(4, 0, 4)
+	for i := 0; i < 10; i++ {
+		h.Fill(1.5, 0.5)
+	}
+	if got, want := h.EffEntries(), 10.0; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid effective entries for uniform weights: got=%v, want=%v", got, want)
+	}
+}
@@ -39,6 +39,14 @@ func NewH2DFromEdges(xedges []float64, yedges []float64) *H2D {
 	}
 }
 
+// Clone returns a deep copy of this 2-dim histogram.
+func (h *H2D) Clone() *H2D {
+	return &H2D{
+		Binning: h.Binning.clone(),
+		Ann:     h.Ann.clone(),
+	}
+}
+
 // Name returns the name of this histogram, if any
 func (h *H2D) Name() string {
 	v, ok := h.Ann["name"]
@@ -246,6 +254,167 @@ func (h *H2D) Integral() float64 {
 	return h.SumW()
 }
 
+// ProfileX returns the profile of this histogram along the X-axis:
+// for each column of bins, the returned profile histogram holds the
+// weighted mean (and associated error) of the Y values of that column.
+//
+// The X-binning of the profile is the one of the histogram.
+// Only the in-range Y bins are taken into account.
+func (h *H2D) ProfileX() *P1D {
+	bng := &h.Binning
+	p := &P1D{
+		bng: newBinningP1DFromBins(bng.XEdges),
+		ann: make(Annotation, len(h.Ann)),
+	}
+	for k, v := range h.Ann {
+		p.ann[k] = v
+	}
+
+	for iy := 0; iy < bng.Ny; iy++ {
+		for ix := 0; ix < bng.Nx; ix++ {
+			d := bng.Bins[iy*bng.Nx+ix].Dist
+			p.bng.bins[ix].dist.addScaled(1, 1, d)
+			p.bng.dist.addScaled(1, 1, d)
+		}
+	}
+	p.bng.outflows[0] = bng.Outflows[BngW-1]
+	p.bng.outflows[1] = bng.Outflows[BngE-1]
+	p.bng.dist.addScaled(1, 1, p.bng.outflows[0])
+	p.bng.dist.addScaled(1, 1, p.bng.outflows[1])
+
+	return p
+}
+
+// ProfileY returns the profile of this histogram along the Y-axis:
+// for each row of bins, the returned profile histogram holds the
+// weighted mean (and associated error) of the X values of that row.
+//
+// The X-binning of the profile is the Y-binning of the histogram.
+// Only the in-range X bins are taken into account.
+func (h *H2D) ProfileY() *P1D {
+	bng := &h.Binning
+	p := &P1D{
+		bng: newBinningP1DFromBins(bng.YEdges),
+		ann: make(Annotation, len(h.Ann)),
+	}
+	for k, v := range h.Ann {
+		p.ann[k] = v
+	}
+
+	for iy := 0; iy < bng.Ny; iy++ {
+		for ix := 0; ix < bng.Nx; ix++ {
+			d := bng.Bins[iy*bng.Nx+ix].Dist.swapXY()
+			p.bng.bins[iy].dist.addScaled(1, 1, d)
+			p.bng.dist.addScaled(1, 1, d)
+		}
+	}
+	p.bng.outflows[0] = bng.Outflows[BngS-1].swapXY()
+	p.bng.outflows[1] = bng.Outflows[BngN-1].swapXY()
+	p.bng.dist.addScaled(1, 1, p.bng.outflows[0])
+	p.bng.dist.addScaled(1, 1, p.bng.outflows[1])
+
+	return p
+}
+
+// SliceX returns the 1-dim histogram of the iy-th row of bins of this
+// histogram: the returned histogram holds the X distributions of the bins
+// of that row.
+//
+// The binning of the returned histogram is the X-binning of the histogram.
+// SliceX returns an error if iy is not a valid row index.
+func (h *H2D) SliceX(iy int) (*H1D, error) {
+	bng := &h.Binning
+	if iy < 0 || iy >= bng.Ny {
+		return nil, fmt.Errorf("hbook: invalid Y-bin index %d (ny=%d)", iy, bng.Ny)
+	}
+
+	o := h.newSlice(bng.XEdges)
+	for ix := range o.Binning.Bins {
+		d := bng.Bins[iy*bng.Nx+ix].Dist.X.clone()
+		o.Binning.Bins[ix].Dist = d
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o, nil
+}
+
+// SliceY returns the 1-dim histogram of the ix-th column of bins of this
+// histogram: the returned histogram holds the Y distributions of the bins
+// of that column.
+//
+// The binning of the returned histogram is the Y-binning of the histogram.
+// SliceY returns an error if ix is not a valid column index.
+func (h *H2D) SliceY(ix int) (*H1D, error) {
+	bng := &h.Binning
+	if ix < 0 || ix >= bng.Nx {
+		return nil, fmt.Errorf("hbook: invalid X-bin index %d (nx=%d)", ix, bng.Nx)
+	}
+
+	o := h.newSlice(bng.YEdges)
+	for iy := range o.Binning.Bins {
+		d := bng.Bins[iy*bng.Nx+ix].Dist.Y.clone()
+		o.Binning.Bins[iy].Dist = d
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o, nil
+}
+
+// Rebin returns a new histogram where each block of gx×gy bins of this
+// histogram has been merged into a single bin, summing their contents.
+//
+// The number of bins along X (resp. Y) must be a multiple of gx (resp. gy).
+// The under- and over-flows are preserved.
+func (h *H2D) Rebin(gx, gy int) (*H2D, error) {
+	bng := &h.Binning
+	switch {
+	case gx <= 0 || gy <= 0:
+		return nil, fmt.Errorf("hbook: invalid rebinning factors (gx=%d, gy=%d)", gx, gy)
+	case bng.Nx%gx != 0:
+		return nil, fmt.Errorf("hbook: number of X-bins (%d) is not a multiple of %d", bng.Nx, gx)
+	case bng.Ny%gy != 0:
+		return nil, fmt.Errorf("hbook: number of Y-bins (%d) is not a multiple of %d", bng.Ny, gy)
+	}
+
+	rebin := func(edges []Bin1D, g int) []float64 {
+		o := make([]float64, 0, len(edges)/g+1)
+		for i := 0; i < len(edges); i += g {
+			o = append(o, edges[i].Range.Min)
+		}
+		return append(o, edges[len(edges)-1].Range.Max)
+	}
+
+	o := &H2D{
+		Binning: newBinning2DFromEdges(rebin(bng.XEdges, gx), rebin(bng.YEdges, gy)),
+		Ann:     h.Ann.clone(),
+	}
+	o.Binning.Dist = bng.Dist.clone()
+	for i, v := range bng.Outflows {
+		o.Binning.Outflows[i] = v.clone()
+	}
+
+	nx := o.Binning.Nx
+	for iy := 0; iy < bng.Ny; iy++ {
+		for ix := 0; ix < bng.Nx; ix++ {
+			bin := &o.Binning.Bins[(iy/gy)*nx+ix/gx]
+			bin.Dist.addScaled(1, 1, bng.Bins[iy*bng.Nx+ix].Dist)
+		}
+	}
+
+	return o, nil
+}
+
+// newSlice returns an empty 1-dim histogram with the provided binning
+// and the annotations of this histogram.
+func (h *H2D) newSlice(edges []Bin1D) *H1D {
+	bins := make([]Range, len(edges))
+	for i, edge := range edges {
+		bins[i] = edge.Range
+	}
+	return &H1D{
+		Binning: newBinning1DFromBins(bins),
+		Ann:     h.Ann.clone(),
+	}
+}
+
 // GridXYZ returns an anonymous struct value that implements
 // gonum/plot/plotter.GridXYZ and is ready to plot.
 func (h *H2D) GridXYZ() h2dGridXYZ {
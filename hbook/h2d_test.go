@@ -5,6 +5,9 @@
 package hbook
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -282,6 +285,57 @@ func TestH2EdgesWithPanics(t *testing.T) {
 // check H2D can be plotted
 var _ plotter.GridXYZ = ((*H2D)(nil)).GridXYZ()
 
+func TestH2DSerialization(t *testing.T) {
+	href := NewH2D(10, -1, 1, 5, 0, 10)
+	href.Annotation()["name"] = "histo name"
+	for i := 0; i < 50; i++ {
+		href.Fill(float64(i)*0.05-1.2, float64(i%12)-0.5, 1.0/float64(i+1))
+	}
+
+	// test gob.GobDecode/gob.GobEncode interface
+	func() {
+		buf := new(bytes.Buffer)
+		err := gob.NewEncoder(buf).Encode(href)
+		if err != nil {
+			t.Fatalf("could not serialize histogram: %v", err)
+		}
+
+		var hnew H2D
+		err = gob.NewDecoder(buf).Decode(&hnew)
+		if err != nil {
+			t.Fatalf("could not deserialize histogram: %v", err)
+		}
+
+		if !reflect.DeepEqual(href, &hnew) {
+			t.Fatalf("ref=%v\nnew=%v\n", href, &hnew)
+		}
+	}()
+
+	// test json.Marshaler/Unmarshaler
+	func() {
+		raw, err := json.Marshal(href)
+		if err != nil {
+			t.Fatalf("could not serialize histogram: %v", err)
+		}
+
+		var hnew H2D
+		err = json.Unmarshal(raw, &hnew)
+		if err != nil {
+			t.Fatalf("could not deserialize histogram: %v", err)
+		}
+
+		if !reflect.DeepEqual(href, &hnew) {
+			t.Fatalf("ref=%v\nnew=%v\n", href, &hnew)
+		}
+		if got, want := hnew.XMean(), href.XMean(); got != want {
+			t.Fatalf("invalid x-mean: got=%v, want=%v", got, want)
+		}
+		if got, want := hnew.YVariance(), href.YVariance(); got != want {
+			t.Fatalf("invalid y-variance: got=%v, want=%v", got, want)
+		}
+	}()
+}
+
 func TestH2DWriteYODA(t *testing.T) {
 	h := NewH2D(5, -1, 1, 5, -2, +2)
 	h.Fill(+0.5, +1, 1)
@@ -487,3 +541,296 @@ func TestH2DFillN(t *testing.T) {
 		h2.FillN(xs, ys, []float64{1})
 	}()
 }
+
+func TestH2DProfile(t *testing.T) {
+	h := NewH2DFromEdges([]float64{0, 1, 3}, []float64{0, 2, 3, 4})
+	h.Annotation()["name"] = "h2"
+	h.Fill(0.5, 0.5, 1)
+	h.Fill(0.5, 2.5, 3)
+	h.Fill(2.0, 1.0, 1)
+	h.Fill(2.5, 3.5, 1)
+	h.Fill(1.5, 1.0, 2)
+	h.Fill(-1, 1, 5)  // x-underflow
+	h.Fill(10, 1, 5)  // x-overflow
+	h.Fill(0.5, 9, 5) // y-overflow
+
+	px := h.ProfileX()
+	if got, want := px.Name(), "h2"; got != want {
+		t.Fatalf("invalid profile name: got=%q, want=%q", got, want)
+	}
+	if got, want := len(px.Binning().Bins()), 2; got != want {
+		t.Fatalf("invalid number of x-bins: got=%d, want=%d", got, want)
+	}
+	if got, want := px.XMax(), 3.0; got != want {
+		t.Fatalf("invalid x-max: got=%v, want=%v", got, want)
+	}
+	for i, want := range []struct {
+		xmin, xmax float64
+		sumw       float64
+		ymean      float64
+	}{
+		{0, 1, 4, 2},
+		{1, 3, 4, 1.625},
+	} {
+		bin := px.Binning().Bins()[i]
+		if bin.XMin() != want.xmin || bin.XMax() != want.xmax {
+			t.Fatalf("x-bin %d: invalid edges: got=[%v, %v), want=[%v, %v)", i, bin.XMin(), bin.XMax(), want.xmin, want.xmax)
+		}
+		if got := bin.SumW(); got != want.sumw {
+			t.Fatalf("x-bin %d: invalid sumw: got=%v, want=%v", i, got, want.sumw)
+		}
+		if got := bin.YMean(); got != want.ymean {
+			t.Fatalf("x-bin %d: invalid y-mean: got=%v, want=%v", i, got, want.ymean)
+		}
+	}
+	if got, want := px.SumW(), 18.0; got != want {
+		t.Fatalf("invalid profile-x sumw: got=%v, want=%v", got, want)
+	}
+
+	py := h.ProfileY()
+	if got, want := len(py.Binning().Bins()), 3; got != want {
+		t.Fatalf("invalid number of y-bins: got=%d, want=%d", got, want)
+	}
+	for i, want := range []struct {
+		sumw  float64
+		xmean float64
+	}{
+		{4, 1.375},
+		{3, 0.5},
+		{1, 2.5},
+	} {
+		bin := py.Binning().Bins()[i]
+		if got := bin.SumW(); got != want.sumw {
+			t.Fatalf("y-bin %d: invalid sumw: got=%v, want=%v", i, got, want.sumw)
+		}
+		if got := bin.YMean(); got != want.xmean {
+			t.Fatalf("y-bin %d: invalid x-mean: got=%v, want=%v", i, got, want.xmean)
+		}
+	}
+	if got, want := py.SumW(), 13.0; got != want {
+		t.Fatalf("invalid profile-y sumw: got=%v, want=%v", got, want)
+	}
+
+	// filling the profile should honor the variable-sized binning.
+	px.Fill(2.9, 1, 1)
+	if got, want := px.Binning().Bins()[1].Entries(), int64(4); got != want {
+		t.Fatalf("invalid entries after fill: got=%d, want=%d", got, want)
+	}
+}
+
+func TestH2DSlice(t *testing.T) {
+	h := NewH2DFromEdges([]float64{0, 1, 3}, []float64{0, 2, 3, 4})
+	h.Annotation()["name"] = "h2"
+	h.Fill(0.5, 0.5, 1)
+	h.Fill(0.5, 2.5, 3)
+	h.Fill(2.0, 1.0, 1)
+	h.Fill(2.5, 3.5, 1)
+	h.Fill(1.5, 1.0, 2)
+	h.Fill(-1, 1, 5)  // x-underflow
+	h.Fill(0.5, 9, 5) // y-overflow
+
+	for _, tc := range []struct {
+		name  string
+		slice func(i int) (*H1D, error)
+		i     int
+		edges []float64
+		sumw  []float64
+		sumw2 []float64
+		mean  float64
+	}{
+		{
+			name:  "x-0",
+			slice: h.SliceX,
+			i:     0,
+			edges: []float64{0, 1, 3},
+			sumw:  []float64{1, 3},
+			sumw2: []float64{1, 5},
+			mean:  (0.5 + 2.0 + 2*1.5) / 4,
+		},
+		{
+			name:  "x-1",
+			slice: h.SliceX,
+			i:     1,
+			edges: []float64{0, 1, 3},
+			sumw:  []float64{3, 0},
+			sumw2: []float64{9, 0},
+			mean:  0.5,
+		},
+		{
+			name:  "y-1",
+			slice: h.SliceY,
+			i:     1,
+			edges: []float64{0, 2, 3, 4},
+			sumw:  []float64{3, 0, 1},
+			sumw2: []float64{5, 0, 1},
+			mean:  (1.0 + 2*1.0 + 3.5) / 4,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h1, err := tc.slice(tc.i)
+			if err != nil {
+				t.Fatalf("could not slice histogram: %+v", err)
+			}
+			if got, want := h1.Name(), "h2"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			bins := h1.Binning.Bins
+			if got, want := len(bins), len(tc.sumw); got != want {
+				t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+			}
+			for i := range bins {
+				bin := &bins[i]
+				if bin.XMin() != tc.edges[i] || bin.XMax() != tc.edges[i+1] {
+					t.Fatalf("bin %d: invalid edges: got=[%v, %v), want=[%v, %v)", i, bin.XMin(), bin.XMax(), tc.edges[i], tc.edges[i+1])
+				}
+				if got, want := bin.SumW(), tc.sumw[i]; got != want {
+					t.Fatalf("bin %d: invalid sumw: got=%v, want=%v", i, got, want)
+				}
+				if got, want := bin.SumW2(), tc.sumw2[i]; got != want {
+					t.Fatalf("bin %d: invalid sumw2: got=%v, want=%v", i, got, want)
+				}
+			}
+			sumw := 0.0
+			for _, v := range tc.sumw {
+				sumw += v
+			}
+			if got, want := h1.SumW(), sumw; got != want {
+				t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+			}
+			if got, want := h1.XMean(), tc.mean; math.Abs(got-want) > 1e-12 {
+				t.Fatalf("invalid mean: got=%v, want=%v", got, want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		slice func(i int) (*H1D, error)
+		i     int
+	}{
+		{"x-neg", h.SliceX, -1},
+		{"x-over", h.SliceX, 3},
+		{"y-neg", h.SliceY, -1},
+		{"y-over", h.SliceY, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.slice(tc.i)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestH2DRebin(t *testing.T) {
+	h := NewH2D(4, 0, 4, 4, 0, 4)
+	h.Annotation()["name"] = "h2"
+	for iy := 0; iy < 4; iy++ {
+		for ix := 0; ix < 4; ix++ {
+			h.Fill(float64(ix)+0.5, float64(iy)+0.5, float64(1+ix+4*iy))
+		}
+	}
+	h.Fill(-1, 1, 5)  // x-underflow
+	h.Fill(0.5, 9, 3) // y-overflow
+
+	o, err := h.Rebin(2, 2)
+	if err != nil {
+		t.Fatalf("could not rebin histogram: %+v", err)
+	}
+
+	if got, want := o.Name(), "h2"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := [2]int{o.Binning.Nx, o.Binning.Ny}, [2]int{2, 2}; got != want {
+		t.Fatalf("invalid number of bins: got=%v, want=%v", got, want)
+	}
+	for i, edge := range o.Binning.XEdges {
+		if got, want := edge.Range, (Range{Min: float64(2 * i), Max: float64(2*i + 2)}); got != want {
+			t.Fatalf("invalid X-edge %d: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	var (
+		sumw  = []float64{1 + 2 + 5 + 6, 3 + 4 + 7 + 8, 9 + 10 + 13 + 14, 11 + 12 + 15 + 16}
+		sumw2 = []float64{
+			1 + 4 + 25 + 36, 9 + 16 + 49 + 64,
+			81 + 100 + 169 + 196, 121 + 144 + 225 + 256,
+		}
+	)
+	for i, bin := range o.Binning.Bins {
+		if got, want := bin.SumW(), sumw[i]; got != want {
+			t.Errorf("bin %d: invalid sumw: got=%v, want=%v", i, got, want)
+		}
+		if got, want := bin.SumW2(), sumw2[i]; got != want {
+			t.Errorf("bin %d: invalid sumw2: got=%v, want=%v", i, got, want)
+		}
+		if got, want := bin.Entries(), int64(4); got != want {
+			t.Errorf("bin %d: invalid entries: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	if got, want := o.Entries(), h.Entries(); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := o.SumW(), h.SumW(); got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if !reflect.DeepEqual(o.Binning.Outflows, h.Binning.Outflows) {
+		t.Fatalf("invalid outflows:\ngot= %+v\nwant=%+v", o.Binning.Outflows, h.Binning.Outflows)
+	}
+
+	for _, tc := range []struct {
+		gx, gy int
+		err    string
+	}{
+		{0, 2, "hbook: invalid rebinning factors (gx=0, gy=2)"},
+		{3, 2, "hbook: number of X-bins (4) is not a multiple of 3"},
+		{2, 3, "hbook: number of Y-bins (4) is not a multiple of 3"},
+	} {
+		_, err := h.Rebin(tc.gx, tc.gy)
+		if err == nil || err.Error() != tc.err {
+			t.Fatalf("rebin(%d, %d): invalid error: got=%v, want=%q", tc.gx, tc.gy, err, tc.err)
+		}
+	}
+}
+
+func TestH2DClone(t *testing.T) {
+	h1 := NewH2D(5, 0, 5, 4, 0, 4)
+	h1.Ann["name"] = "h1"
+	h1.FillN(
+		[]float64{-1, 0, 1, 2, 3, 4, 6},
+		[]float64{0, 1, 2, 3, 4, 1, 2},
+		[]float64{1, 2, 3, 4, 5, 6, 7},
+	)
+
+	msg1, err := h1.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal h1: %+v", err)
+	}
+
+	h2 := h1.Clone()
+	msg2, err := h2.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal h2: %+v", err)
+	}
+
+	if !reflect.DeepEqual(msg1, msg2) {
+		t.Fatalf("h2d file differ:\n%s\n", cmp.Diff(string(msg1), string(msg2)))
+	}
+
+	h2.Ann["name"] = "h2"
+	h2.FillN(
+		[]float64{-1, 0, 1, 2, 3, 4, 6},
+		[]float64{0, 1, 2, 3, 4, 1, 2},
+		nil,
+	)
+
+	msg3, err := h1.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal h1: %+v", err)
+	}
+
+	if !reflect.DeepEqual(msg1, msg3) {
+		t.Fatalf("h1 was modified by its clone:\n%s\n", cmp.Diff(string(msg1), string(msg3)))
+	}
+}
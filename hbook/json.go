@@ -0,0 +1,273 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonDist1D is the JSON representation of a 1-dim distribution.
+type jsonDist1D struct {
+	N      int64   `json:"n"`
+	SumW   float64 `json:"sumw"`
+	SumW2  float64 `json:"sumw2"`
+	SumWX  float64 `json:"sumwx"`
+	SumWX2 float64 `json:"sumwx2"`
+}
+
+func newJSONDist1D(d Dist1D) jsonDist1D {
+	return jsonDist1D{
+		N:      d.Dist.N,
+		SumW:   d.Dist.SumW,
+		SumW2:  d.Dist.SumW2,
+		SumWX:  d.Stats.SumWX,
+		SumWX2: d.Stats.SumWX2,
+	}
+}
+
+func (j jsonDist1D) dist() Dist1D {
+	var d Dist1D
+	d.Dist = Dist0D{N: j.N, SumW: j.SumW, SumW2: j.SumW2}
+	d.Stats.SumWX = j.SumWX
+	d.Stats.SumWX2 = j.SumWX2
+	return d
+}
+
+// jsonDist2D is the JSON representation of a 2-dim distribution.
+type jsonDist2D struct {
+	N      int64   `json:"n"`
+	SumW   float64 `json:"sumw"`
+	SumW2  float64 `json:"sumw2"`
+	SumWX  float64 `json:"sumwx"`
+	SumWX2 float64 `json:"sumwx2"`
+	SumWY  float64 `json:"sumwy"`
+	SumWY2 float64 `json:"sumwy2"`
+	SumWXY float64 `json:"sumwxy"`
+}
+
+func newJSONDist2D(d Dist2D) jsonDist2D {
+	return jsonDist2D{
+		N:      d.X.Dist.N,
+		SumW:   d.X.Dist.SumW,
+		SumW2:  d.X.Dist.SumW2,
+		SumWX:  d.X.Stats.SumWX,
+		SumWX2: d.X.Stats.SumWX2,
+		SumWY:  d.Y.Stats.SumWX,
+		SumWY2: d.Y.Stats.SumWX2,
+		SumWXY: d.Stats.SumWXY,
+	}
+}
+
+func (j jsonDist2D) dist() Dist2D {
+	var d Dist2D
+	d.X.Dist = Dist0D{N: j.N, SumW: j.SumW, SumW2: j.SumW2}
+	d.X.Stats.SumWX = j.SumWX
+	d.X.Stats.SumWX2 = j.SumWX2
+	d.Y.Dist = d.X.Dist
+	d.Y.Stats.SumWX = j.SumWY
+	d.Y.Stats.SumWX2 = j.SumWY2
+	d.Stats.SumWXY = j.SumWXY
+	return d
+}
+
+type jsonBin1D struct {
+	XMin float64 `json:"xmin"`
+	XMax float64 `json:"xmax"`
+	jsonDist1D
+}
+
+type jsonH1D struct {
+	Type      string      `json:"type"`
+	Ann       Annotation  `json:"annotation"`
+	XRange    [2]float64  `json:"xrange"`
+	Total     jsonDist1D  `json:"total"`
+	Underflow jsonDist1D  `json:"underflow"`
+	Overflow  jsonDist1D  `json:"overflow"`
+	Bins      []jsonBin1D `json:"bins"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The histogram is encoded with its annotation, binning, total and
+// under/over-flow distributions and the distribution of each bin.
+// Numbers are encoded with the shortest representation which decodes
+// to the exact same value.
+func (h *H1D) MarshalJSON() ([]byte, error) {
+	bng := &h.Binning
+	v := jsonH1D{
+		Type:      "H1D",
+		Ann:       h.Ann,
+		XRange:    [2]float64{bng.XRange.Min, bng.XRange.Max},
+		Total:     newJSONDist1D(bng.Dist),
+		Underflow: newJSONDist1D(bng.Outflows[0]),
+		Overflow:  newJSONDist1D(bng.Outflows[1]),
+		Bins:      make([]jsonBin1D, len(bng.Bins)),
+	}
+	if v.Ann == nil {
+		v.Ann = make(Annotation)
+	}
+	for i, bin := range bng.Bins {
+		v.Bins[i] = jsonBin1D{
+			XMin:       bin.Range.Min,
+			XMax:       bin.Range.Max,
+			jsonDist1D: newJSONDist1D(bin.Dist),
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Numbers stored in the annotation are decoded as float64.
+func (h *H1D) UnmarshalJSON(data []byte) error {
+	var v jsonH1D
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return fmt.Errorf("hbook: could not unmarshal H1D from JSON: %w", err)
+	}
+	if v.Type != "H1D" {
+		return fmt.Errorf("hbook: invalid JSON type (got=%q, want=%q)", v.Type, "H1D")
+	}
+
+	bng := Binning1D{
+		Bins:     make([]Bin1D, len(v.Bins)),
+		Dist:     v.Total.dist(),
+		Outflows: [2]Dist1D{v.Underflow.dist(), v.Overflow.dist()},
+		XRange:   Range{Min: v.XRange[0], Max: v.XRange[1]},
+	}
+	for i, bin := range v.Bins {
+		bng.Bins[i] = Bin1D{
+			Range: Range{Min: bin.XMin, Max: bin.XMax},
+			Dist:  bin.dist(),
+		}
+	}
+
+	h.Binning = bng
+	h.Ann = v.Ann
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	return nil
+}
+
+type jsonBin2D struct {
+	XMin float64 `json:"xmin"`
+	XMax float64 `json:"xmax"`
+	YMin float64 `json:"ymin"`
+	YMax float64 `json:"ymax"`
+	jsonDist2D
+}
+
+type jsonH2D struct {
+	Type     string        `json:"type"`
+	Ann      Annotation    `json:"annotation"`
+	XRange   [2]float64    `json:"xrange"`
+	YRange   [2]float64    `json:"yrange"`
+	XEdges   [][2]float64  `json:"xedges"`
+	YEdges   [][2]float64  `json:"yedges"`
+	Total    jsonDist2D    `json:"total"`
+	Outflows [8]jsonDist2D `json:"outflows"`
+	Bins     []jsonBin2D   `json:"bins"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The histogram is encoded with its annotation, binning, total and
+// outflow distributions and the distribution of each bin.
+// Outflows are stored in the order of the BngNW...BngW indices.
+// Numbers are encoded with the shortest representation which decodes
+// to the exact same value.
+func (h *H2D) MarshalJSON() ([]byte, error) {
+	bng := &h.Binning
+	v := jsonH2D{
+		Type:   "H2D",
+		Ann:    h.Ann,
+		XRange: [2]float64{bng.XRange.Min, bng.XRange.Max},
+		YRange: [2]float64{bng.YRange.Min, bng.YRange.Max},
+		XEdges: make([][2]float64, len(bng.XEdges)),
+		YEdges: make([][2]float64, len(bng.YEdges)),
+		Total:  newJSONDist2D(bng.Dist),
+		Bins:   make([]jsonBin2D, len(bng.Bins)),
+	}
+	if v.Ann == nil {
+		v.Ann = make(Annotation)
+	}
+	for i, bin := range bng.XEdges {
+		v.XEdges[i] = [2]float64{bin.Range.Min, bin.Range.Max}
+	}
+	for i, bin := range bng.YEdges {
+		v.YEdges[i] = [2]float64{bin.Range.Min, bin.Range.Max}
+	}
+	for i, d := range bng.Outflows {
+		v.Outflows[i] = newJSONDist2D(d)
+	}
+	for i, bin := range bng.Bins {
+		v.Bins[i] = jsonBin2D{
+			XMin:       bin.XRange.Min,
+			XMax:       bin.XRange.Max,
+			YMin:       bin.YRange.Min,
+			YMax:       bin.YRange.Max,
+			jsonDist2D: newJSONDist2D(bin.Dist),
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Numbers stored in the annotation are decoded as float64.
+func (h *H2D) UnmarshalJSON(data []byte) error {
+	var v jsonH2D
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return fmt.Errorf("hbook: could not unmarshal H2D from JSON: %w", err)
+	}
+	if v.Type != "H2D" {
+		return fmt.Errorf("hbook: invalid JSON type (got=%q, want=%q)", v.Type, "H2D")
+	}
+	if nx, ny := len(v.XEdges), len(v.YEdges); nx*ny != len(v.Bins) {
+		return fmt.Errorf("hbook: invalid number of H2D bins (got=%d, want=%dx%d)", len(v.Bins), nx, ny)
+	}
+
+	bng := Binning2D{
+		Bins:   make([]Bin2D, len(v.Bins)),
+		Dist:   v.Total.dist(),
+		XRange: Range{Min: v.XRange[0], Max: v.XRange[1]},
+		YRange: Range{Min: v.YRange[0], Max: v.YRange[1]},
+		Nx:     len(v.XEdges),
+		Ny:     len(v.YEdges),
+		XEdges: make([]Bin1D, len(v.XEdges)),
+		YEdges: make([]Bin1D, len(v.YEdges)),
+	}
+	for i, d := range v.Outflows {
+		bng.Outflows[i] = d.dist()
+	}
+	for i, r := range v.XEdges {
+		bng.XEdges[i].Range = Range{Min: r[0], Max: r[1]}
+	}
+	for i, r := range v.YEdges {
+		bng.YEdges[i].Range = Range{Min: r[0], Max: r[1]}
+	}
+	for i, bin := range v.Bins {
+		bng.Bins[i] = Bin2D{
+			XRange: Range{Min: bin.XMin, Max: bin.XMax},
+			YRange: Range{Min: bin.YMin, Max: bin.YMax},
+			Dist:   bin.dist(),
+		}
+	}
+
+	h.Binning = bng
+	h.Ann = v.Ann
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	return nil
+}
+
+var _ json.Marshaler = (*H1D)(nil)
+var _ json.Unmarshaler = (*H1D)(nil)
+var _ json.Marshaler = (*H2D)(nil)
+var _ json.Unmarshaler = (*H2D)(nil)
@@ -38,6 +38,25 @@ func DivideH1D(num, den *H1D, opts ...DivOptions) (*S2D, error) {
 		exm := x - b1.XMin()
 		exp := b1.XMax() - x
 
+		if cfg.binomial {
+			if b2.SumW() == 0 {
+				continue
+			}
+			eff := b1.SumW() / b2.SumW()
+			if eff < 0 || eff > 1 {
+				return nil, fmt.Errorf("hbook: efficiency %v out of [0,1] in bin %d of %v / %v", eff, i, num.Name(), den.Name())
+			}
+			// effective number of entries of the denominator
+			n := b2.SumW() * b2.SumW() / b2.SumW2()
+			err := math.Sqrt(eff * (1 - eff) / n)
+			s2d.Fill(Point2D{
+				X: x, Y: eff,
+				ErrX: Range{Min: exm, Max: exp},
+				ErrY: Range{Min: math.Min(err, eff), Max: math.Min(err, 1-eff)},
+			})
+			continue
+		}
+
 		// assemble the y value and error
 		var y, ey float64
 		b2h := b2.SumW() / b2.XWidth() // height of the bin
@@ -85,6 +104,7 @@ type DivOptions func(c *divConfig)
 type divConfig struct {
 	ignoreNaN  bool
 	replaceNaN float64
+	binomial   bool
 }
 
 // newDivConfig function builds the default configuration
@@ -109,6 +129,20 @@ func DivReplaceNaNs(v float64) DivOptions {
 	}
 }
 
+// DivBinomial function configures DivideH1D to compute efficiencies,
+// where the numerator is a subset of the denominator.
+// Errors on the efficiency are computed with the binomial normal
+// approximation, sqrt(eff*(1-eff)/n), where n is the effective number
+// of entries of the denominator bin, and are clipped to stay within [0, 1].
+// Bins with an empty denominator are omitted.
+// DivideH1D returns an error if an efficiency falls outside of [0, 1],
+// e.g. when the numerator is not a subset of the denominator.
+func DivBinomial() DivOptions {
+	return func(c *divConfig) {
+		c.binomial = true
+	}
+}
+
 // fuzzyEq returns true if a and b are equal with a degree of fuzziness
 func fuzzyEq(a, b float64) bool {
 	const tol = 1e-5
@@ -157,3 +191,73 @@ func AddH1D(h1, h2 *H1D) *H1D {
 func SubH1D(h1, h2 *H1D) *H1D {
 	return AddScaledH1D(h1, -1, h2)
 }
+
+// ResidualsH1D returns the residuals of the histogram h with respect to
+// the reference function f, as a 2D scatter.
+//
+// Each point is located at the center of a bin, with a value equal to the
+// sum of weights of the bin minus f evaluated at the center of the bin.
+// The x errors span the bin and the y errors are the statistical
+// uncertainties of the bin, sqrt(sumw2).
+// Bins where f evaluates to NaN or ±Inf are omitted.
+func ResidualsH1D(h *H1D, f func(x float64) float64) *S2D {
+	var s2d S2D
+	for _, bin := range h.Binning.Bins {
+		x := bin.XMid()
+		ref := f(x)
+		if math.IsNaN(ref) || math.IsInf(ref, 0) {
+			continue
+		}
+		ey := bin.ErrW()
+		s2d.Fill(Point2D{
+			X:    x,
+			Y:    bin.SumW() - ref,
+			ErrX: Range{Min: x - bin.XMin(), Max: bin.XMax() - x},
+			ErrY: Range{Min: ey, Max: ey},
+		})
+	}
+	return &s2d
+}
+
+// MergeH1D returns the bin-by-bin sum of all the provided histograms,
+// assuming their statistical uncertainties are uncorrelated.
+// Under- and over-flows are summed as well.
+//
+// MergeH1D returns an error if no histogram is provided or if the
+// binnings of the histograms are not identical.
+func MergeH1D(hs ...*H1D) (*H1D, error) {
+	if len(hs) == 0 {
+		return nil, fmt.Errorf("hbook: no histogram to merge")
+	}
+
+	ref := hs[0]
+	for i, h := range hs[1:] {
+		if h.Len() != ref.Len() {
+			return nil, fmt.Errorf(
+				"hbook: histogram #%d (%q) has %d bins, histogram #0 (%q) has %d bins",
+				i+1, h.Name(), h.Len(), ref.Name(), ref.Len(),
+			)
+		}
+		for j := range h.Binning.Bins {
+			b1 := ref.Binning.Bins[j].Range
+			b2 := h.Binning.Bins[j].Range
+			if b1 != b2 {
+				return nil, fmt.Errorf(
+					"hbook: histogram #%d (%q) has bin #%d [%v, %v), histogram #0 (%q) has [%v, %v)",
+					i+1, h.Name(), j, b2.Min, b2.Max, ref.Name(), b1.Min, b1.Max,
+				)
+			}
+		}
+	}
+
+	o := ref.Clone()
+	for _, h := range hs[1:] {
+		for i := range o.Binning.Bins {
+			o.Binning.Bins[i].addScaled(1, 1, h.Binning.Bins[i])
+		}
+		o.Binning.Dist.addScaled(1, 1, h.Binning.Dist)
+		o.Binning.Outflows[0].addScaled(1, 1, h.Binning.Outflows[0])
+		o.Binning.Outflows[1].addScaled(1, 1, h.Binning.Outflows[1])
+	}
+	return o, nil
+}
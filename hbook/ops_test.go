@@ -7,10 +7,12 @@ package hbook
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gonum.org/v1/gonum/floats"
 )
 
 func ExampleDivideH1D() {
@@ -121,6 +123,78 @@ END YODA_SCATTER2D_V2
 	}
 }
 
+func TestDivideH1DBinomial(t *testing.T) {
+	num := NewH1D(5, 0, 5)
+	den := NewH1D(5, 0, 5)
+	for i, n := range []int{0, 1, 2, 4, 0} {
+		for j := 0; j < n; j++ {
+			num.Fill(float64(i), 1)
+		}
+		if i == 4 {
+			continue // leave the last denominator bin empty.
+		}
+		for j := 0; j < 4; j++ {
+			den.Fill(float64(i), 1)
+		}
+	}
+
+	s, err := DivideH1D(num, den, DivBinomial())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Point2D{
+		{X: 0.5, Y: 0.00, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 0, Max: 0}},
+		{X: 1.5, Y: 0.25, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: math.Sqrt(0.1875 / 4), Max: math.Sqrt(0.1875 / 4)}},
+		{X: 2.5, Y: 0.50, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 0.25, Max: 0.25}},
+		{X: 3.5, Y: 1.00, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 0, Max: 0}},
+	}
+
+	if got := s.Points(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid efficiency points:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestDivideH1DBinomialWeighted(t *testing.T) {
+	num := NewH1D(1, 0, 1)
+	den := NewH1D(1, 0, 1)
+	for _, w := range []float64{2, 2, 1, 1, 1, 1} {
+		den.Fill(0.5, w)
+	}
+	for _, w := range []float64{2, 1, 1} {
+		num.Fill(0.5, w)
+	}
+
+	s, err := DivideH1D(num, den, DivBinomial())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// eff = 4/8, with n_eff = 8^2/12 effective entries.
+	want := []Point2D{
+		{X: 0.5, Y: 0.5, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: math.Sqrt(3) / 8, Max: math.Sqrt(3) / 8}},
+	}
+	got := s.Points()
+	if len(got) != len(want) {
+		t.Fatalf("invalid number of points: got=%d, want=%d", len(got), len(want))
+	}
+	for i := range got {
+		g, w := got[i], want[i]
+		if g.X != w.X || g.Y != w.Y || g.ErrX != w.ErrX ||
+			!floats.EqualWithinAbs(g.ErrY.Min, w.ErrY.Min, 1e-12) ||
+			!floats.EqualWithinAbs(g.ErrY.Max, w.ErrY.Max, 1e-12) {
+			t.Fatalf("invalid efficiency point #%d:\ngot= %v\nwant=%v", i, g, w)
+		}
+	}
+
+	// numerator is not a subset of the denominator.
+	num.Fill(0.5, 10)
+	_, err = DivideH1D(num, den, DivBinomial())
+	if err == nil {
+		t.Fatalf("expected an error for an efficiency larger than 1")
+	}
+}
+
 func TestAddH1DPanics(t *testing.T) {
 	for _, tc := range []struct {
 		h1, h2 *H1D
@@ -327,3 +401,94 @@ END YODA_HISTO1D_V2
 		)
 	}
 }
+
+func TestResidualsH1D(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	h.Fill(0.5, 2)
+	h.Fill(0.5, 2)
+	h.Fill(1.5, 3)
+	h.Fill(2.5, 1)
+	h.Fill(3.5, 5)
+
+	f := func(x float64) float64 {
+		if x > 3 {
+			return math.Inf(+1)
+		}
+		if x > 2 {
+			return math.NaN()
+		}
+		return 2 * x
+	}
+
+	s := ResidualsH1D(h, f)
+	want := []Point2D{
+		{X: 0.5, Y: 3, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: math.Sqrt(8), Max: math.Sqrt(8)}},
+		{X: 1.5, Y: 0, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 3, Max: 3}},
+	}
+	if got := s.Points(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid residuals:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
+
+func TestMergeH1D(t *testing.T) {
+	var hs []*H1D
+	for i := 0; i < 5; i++ {
+		h := NewH1D(6, 0, 6)
+		for j := 0; j < 10; j++ {
+			h.Fill(float64(i+j)*0.7-1, float64(j%3)+0.5)
+		}
+		hs = append(hs, h)
+	}
+
+	want := hs[0]
+	for _, h := range hs[1:] {
+		want = AddH1D(want, h)
+	}
+
+	got, err := MergeH1D(hs...)
+	if err != nil {
+		t.Fatalf("could not merge histograms: %+v", err)
+	}
+
+	if !reflect.DeepEqual(got.Binning, want.Binning) {
+		t.Fatalf("merge differ:\ngot= %+v\nwant=%+v", got.Binning, want.Binning)
+	}
+
+	if got, want := got.Entries(), int64(50); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	for i := range hs {
+		if got, want := hs[i].Entries(), int64(10); got != want {
+			t.Fatalf("input histogram #%d was modified", i)
+		}
+	}
+
+	for _, tc := range []struct {
+		hs  []*H1D
+		err string
+	}{
+		{
+			hs:  nil,
+			err: "hbook: no histogram to merge",
+		},
+		{
+			hs:  []*H1D{hs[0], hs[1], NewH1D(5, 0, 6)},
+			err: `hbook: histogram #2 ("") has 5 bins, histogram #0 ("") has 6 bins`,
+		},
+		{
+			hs:  []*H1D{hs[0], NewH1DFromEdges([]float64{0, 1, 2, 3.5, 4, 5, 6})},
+			err: `hbook: histogram #1 ("") has bin #2 [2, 3.5), histogram #0 ("") has [2, 3)`,
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			_, err := MergeH1D(tc.hs...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 )
 
@@ -126,6 +127,36 @@ func (p *P1D) XRMS() float64 {
 	return p.bng.dist.xRMS()
 }
 
+// YMean returns the mean Y.
+// Overflows are included in the computation.
+func (p *P1D) YMean() float64 {
+	return p.bng.dist.yMean()
+}
+
+// YVariance returns the variance in Y.
+// Overflows are included in the computation.
+func (p *P1D) YVariance() float64 {
+	return p.bng.dist.yVariance()
+}
+
+// YStdDev returns the standard deviation in Y.
+// Overflows are included in the computation.
+func (p *P1D) YStdDev() float64 {
+	return p.bng.dist.yStdDev()
+}
+
+// YStdErr returns the standard error in Y.
+// Overflows are included in the computation.
+func (p *P1D) YStdErr() float64 {
+	return p.bng.dist.yStdErr()
+}
+
+// YRMS returns the RMS in Y.
+// Overflows are included in the computation.
+func (p *P1D) YRMS() float64 {
+	return p.bng.dist.yRMS()
+}
+
 // Fill fills this histogram with x,y and weight w.
 func (p *P1D) Fill(x, y, w float64) {
 	p.bng.fill(x, y, w)
@@ -590,6 +621,23 @@ func newBinningP1D(n int, xmin, xmax float64) binningP1D {
 	return bng
 }
 
+// newBinningP1DFromBins creates a binning for a 1-dim profile histogram
+// with the same (possibly variable-sized) bins than the provided ones.
+func newBinningP1DFromBins(xbins []Bin1D) binningP1D {
+	if len(xbins) < 1 {
+		panic(errShortXAxis)
+	}
+	n := len(xbins)
+	bng := binningP1D{
+		bins:   make([]BinP1D, n),
+		xrange: Range{Min: xbins[0].XMin(), Max: xbins[n-1].XMax()},
+	}
+	for i := range bng.bins {
+		bng.bins[i].xrange = xbins[i].Range
+	}
+	return bng
+}
+
 func (bng *binningP1D) entries() int64 {
 	return bng.dist.Entries()
 }
@@ -622,6 +670,12 @@ func (bng *binningP1D) fill(x, y, w float64) {
 func (bng *binningP1D) coordToIndex(x float64) int {
 	switch {
 	default:
+		if bng.xstep == 0 {
+			// variable-sized bins.
+			return sort.Search(len(bng.bins), func(i int) bool {
+				return x < bng.bins[i].xrange.Max
+			})
+		}
 		i := int((x - bng.xrange.Min) * bng.xstep)
 		return i
 	case x < bng.xrange.Min:
@@ -742,3 +796,28 @@ func (b *BinP1D) XStdErr() float64 {
 func (b *BinP1D) XRMS() float64 {
 	return b.dist.xRMS()
 }
+
+// YMean returns the mean Y.
+func (b *BinP1D) YMean() float64 {
+	return b.dist.yMean()
+}
+
+// YVariance returns the variance in Y.
+func (b *BinP1D) YVariance() float64 {
+	return b.dist.yVariance()
+}
+
+// YStdDev returns the standard deviation in Y.
+func (b *BinP1D) YStdDev() float64 {
+	return b.dist.yStdDev()
+}
+
+// YStdErr returns the standard error in Y, i.e. the error on YMean.
+func (b *BinP1D) YStdErr() float64 {
+	return b.dist.yStdErr()
+}
+
+// YRMS returns the RMS in Y.
+func (b *BinP1D) YRMS() float64 {
+	return b.dist.yRMS()
+}
@@ -8,10 +8,12 @@ import (
 	"bytes"
 	"encoding/gob"
 	"io/ioutil"
+	"math"
 	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gonum.org/v1/gonum/floats"
 )
 
 func TestP1D(t *testing.T) {
@@ -90,6 +92,59 @@ func TestP1D(t *testing.T) {
 	}
 }
 
+func TestP1DBins(t *testing.T) {
+	p := NewP1D(2, 0, 2)
+	p.Annotation()["name"] = "p1d"
+	p.Fill(0.5, 1, 1)
+	p.Fill(0.5, 3, 1)
+	p.Fill(0.5, 5, 2)
+	p.Fill(1.5, 10, 1)
+	p.Fill(1.5, 20, 1)
+
+	bins := p.Binning().Bins()
+	for _, tc := range []struct {
+		name string
+		f    func() float64
+		want float64
+	}{
+		{"bin0-ymean", bins[0].YMean, 3.5},
+		{"bin0-yvariance", bins[0].YVariance, 4.4},
+		{"bin0-ystderr", bins[0].YStdErr, math.Sqrt(4.4 * 6 / 16)},
+		{"bin1-ymean", bins[1].YMean, 15},
+		{"bin1-ystddev", bins[1].YStdDev, math.Sqrt(50)},
+		{"bin1-ystderr", bins[1].YStdErr, 5},
+		{"ymean", p.YMean, 44.0 / 6.0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.f(); !floats.EqualWithinAbs(got, tc.want, 1e-12) {
+				t.Fatalf("got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+
+	s := NewS2DFromP1D(p)
+	if got, want := s.Name(), p.Name(); got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := s.Len(), 2; got != want {
+		t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+	}
+	for i, bin := range bins {
+		pt := s.Point(i)
+		if got, want := pt.Y, bin.YMean(); got != want {
+			t.Fatalf("point %d: invalid y: got=%v, want=%v", i, got, want)
+		}
+		if got, want := pt.ErrY, (Range{Min: bin.YStdErr(), Max: bin.YStdErr()}); got != want {
+			t.Fatalf("point %d: invalid y-err: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	s = NewS2DFromP1D(NewP1D(2, 0, 2))
+	if y := s.Point(0).Y; !math.IsNaN(y) {
+		t.Fatalf("invalid y for empty bin: got=%v, want=NaN", y)
+	}
+}
+
 func TestP1DWriteYODA(t *testing.T) {
 	p := NewP1D(10, -4, +4)
 	if p == nil {
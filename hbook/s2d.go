@@ -99,12 +99,16 @@ func NewS2DFromH1D(h *H1D, opts ...S2DOpts) *S2D {
 }
 
 // NewS2DFromP1D creates a new 2-dim scatter from the given P1D.
+// Each point is located at the mean Y of the corresponding bin, with a
+// Y-error equal to the standard error on that mean (or the standard
+// deviation, if UseStdDev is set.)
+// Bins with no entries are converted to NaN points.
 // NewS2DFromP1D optionally takes a S2DOpts slice:
 // only the first element is considered.
 func NewS2DFromP1D(p *P1D, opts ...S2DOpts) *S2D {
 	s := NewS2D()
 	for k, v := range p.ann {
-		p.ann[k] = v
+		s.ann[k] = v
 	}
 	var opt S2DOpts
 	if len(opts) > 0 {
@@ -124,13 +128,12 @@ func NewS2DFromP1D(p *P1D, opts ...S2DOpts) *S2D {
 		exm := x - bin.XMin()
 		exp := bin.XMax() - x
 		var y, ey float64
-		if w := bin.XWidth(); w != 0 {
-			ww := 1 / w
-			y = bin.SumW() * ww
+		if bin.SumW() != 0 {
+			y = bin.YMean()
 			if opt.UseStdDev {
-				ey = bin.XStdDev()
+				ey = bin.YStdDev()
 			} else {
-				ey = bin.XStdErr()
+				ey = bin.YStdErr()
 			}
 		} else {
 			y = math.NaN()  // FIXME(sbinet): use quiet-NaN ?
@@ -41,6 +41,51 @@ func TestReadWrite(t *testing.T) {
 	}
 }
 
+func TestRoundTripH1D(t *testing.T) {
+	href := hbook.NewH1D(20, -5, 5)
+	href.Annotation()["name"] = "h1d-rt"
+	href.Annotation()["title"] = "round-trip"
+	for i := 0; i < 200; i++ {
+		x := float64(i%23)*0.5 - 6
+		href.Fill(x, float64(i%3)+0.5)
+	}
+
+	w := new(bytes.Buffer)
+	err := yodacnv.Write(w, href)
+	if err != nil {
+		t.Fatalf("could not write H1D: %+v", err)
+	}
+
+	objs, err := yodacnv.Read(w)
+	if err != nil {
+		t.Fatalf("could not read H1D: %+v", err)
+	}
+	if got, want := len(objs), 1; got != want {
+		t.Fatalf("invalid number of objects: got=%d, want=%d", got, want)
+	}
+
+	hchk, ok := objs[0].(*hbook.H1D)
+	if !ok {
+		t.Fatalf("invalid object type: got=%T, want=*hbook.H1D", objs[0])
+	}
+
+	if got, want := hchk.Name(), href.Name(); got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := hchk.Entries(), href.Entries(); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := hchk.XMean(), href.XMean(); got != want {
+		t.Fatalf("invalid mean: got=%v, want=%v", got, want)
+	}
+	if got, want := hchk.XRMS(), href.XRMS(); got != want {
+		t.Fatalf("invalid rms: got=%v, want=%v", got, want)
+	}
+	if !reflect.DeepEqual(hchk.Binning, href.Binning) {
+		t.Fatalf("invalid binning:\ngot= %#v\nwant=%#v", hchk.Binning, href.Binning)
+	}
+}
+
 func TestReadCounter(t *testing.T) {
 	r := bytes.NewReader([]byte(`BEGIN YODA_COUNTER /_EVTCOUNT
 Path=/_EVTCOUNT
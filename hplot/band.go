@@ -10,6 +10,7 @@ import (
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
@@ -86,9 +87,28 @@ func (band *Band) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return xmin, xmax, ymin, ymax
 }
 
+// Thumbnail draws a rectangle in the given style of the band,
+// implementing the plot.Thumbnailer interface.
+func (band *Band) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Min.Y},
+	}
+	if band.FillColor != nil {
+		c.FillPolygon(band.FillColor, c.ClipPolygonXY(pts))
+	}
+	if band.LineStyle.Width != 0 {
+		c.StrokeLines(band.LineStyle, c.ClipLinesXY(pts)...)
+	}
+}
+
 var (
-	_ plot.Plotter    = (*VertLine)(nil)
-	_ plot.Plotter    = (*HorizLine)(nil)
-	_ plot.Plotter    = (*Band)(nil)
-	_ plot.DataRanger = (*Band)(nil)
+	_ plot.Plotter     = (*VertLine)(nil)
+	_ plot.Plotter     = (*HorizLine)(nil)
+	_ plot.Plotter     = (*Band)(nil)
+	_ plot.DataRanger  = (*Band)(nil)
+	_ plot.Thumbnailer = (*Band)(nil)
 )
@@ -13,6 +13,7 @@ import (
 	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/stat/distuv"
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -416,3 +417,140 @@ func ExampleH1D_withPlotBorders() {
 		log.Fatalf("error saving plot: %v\n", err)
 	}
 }
+
+// An example of drawing 1D-histograms with the different draw modes.
+func ExampleH1D_drawMode() {
+	const npoints = 1000
+
+	p := hplot.New()
+	p.Title.Text = "Histogram draw modes"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	for i, tc := range []struct {
+		name string
+		mode hplot.DrawMode
+		mu   float64
+	}{
+		{"Steps", hplot.Steps, -2},
+		{"StepsMid", hplot.StepsMid, 0},
+		{"Points", hplot.Points, +2},
+	} {
+		dist := distuv.Normal{
+			Mu:    tc.mu,
+			Sigma: 0.5,
+			Src:   rand.New(rand.NewSource(uint64(i))),
+		}
+
+		hist := hbook.NewH1D(40, -4, +4)
+		for j := 0; j < npoints; j++ {
+			hist.Fill(dist.Rand(), 1)
+		}
+
+		h := hplot.NewH1D(hist, hplot.WithDrawMode(tc.mode))
+		h.LineStyle.Color = plotutil.Color(i)
+		p.Add(h)
+		p.Legend.Add(tc.name, h)
+	}
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	p.Add(hplot.NewGrid())
+
+	err := p.Save(6*vg.Inch, -1, "testdata/h1d_draw_mode.png")
+	if err != nil {
+		log.Fatalf("error saving plot: %v\n", err)
+	}
+}
+
+// An example of a cutflow histogram displaying the content of each bin.
+func ExampleH1D_withValues() {
+	cuts := []struct {
+		name string
+		n    float64
+	}{
+		{"all", 1000},
+		{"trigger", 734},
+		{"2 leptons", 412},
+		{"MET > 40", 203},
+		{"b-tag", 87},
+		{"mass window", 0},
+	}
+
+	hist := hbook.NewH1D(len(cuts), 0, float64(len(cuts)))
+	for i, cut := range cuts {
+		if cut.n == 0 {
+			continue
+		}
+		hist.Fill(float64(i)+0.5, cut.n)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Cutflow"
+	p.X.Label.Text = "Selection"
+	p.Y.Label.Text = "Events"
+
+	h := hplot.NewH1D(hist)
+	h.FillColor = color.RGBA{R: 190, G: 210, B: 255, A: 255}
+	h.ShowValues = true
+	h.ValuesFormat = "%.0f"
+	p.Add(h)
+
+	ticks := make([]plot.Tick, len(cuts))
+	for i, cut := range cuts {
+		ticks[i] = plot.Tick{Value: float64(i) + 0.5, Label: cut.name}
+	}
+	p.X.Tick.Marker = plot.ConstantTicks(ticks)
+
+	hplot.PadRange(p.Plot, 0.1)
+
+	err := p.Save(6*vg.Inch, -1, "testdata/h1d_values.png")
+	if err != nil {
+		log.Fatalf("error saving plot: %v\n", err)
+	}
+}
+
+func ExampleH1D_withFillColors() {
+	cuts := []struct {
+		name string
+		n    float64
+	}{
+		{"all", 1000},
+		{"trigger", 734},
+		{"2 leptons", 412},
+		{"MET > 40", 203},
+		{"b-tag", 87},
+		{"mass window", 31},
+	}
+
+	hist := hbook.NewH1D(len(cuts), 0, float64(len(cuts)))
+	for i, cut := range cuts {
+		hist.Fill(float64(i)+0.5, cut.n)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Cutflow"
+	p.X.Label.Text = "Selection"
+	p.Y.Label.Text = "Events"
+
+	h := hplot.NewH1D(hist)
+	h.FillColor = color.RGBA{R: 190, G: 210, B: 255, A: 255}
+
+	// highlight the signal region, the other bins use FillColor.
+	highlight := color.RGBA{R: 255, G: 160, B: 120, A: 255}
+	h.FillColors = []color.Color{4: highlight, 5: highlight}
+	p.Add(h)
+
+	ticks := make([]plot.Tick, len(cuts))
+	for i, cut := range cuts {
+		ticks[i] = plot.Tick{Value: float64(i) + 0.5, Label: cut.name}
+	}
+	p.X.Tick.Marker = plot.ConstantTicks(ticks)
+
+	hplot.PadRange(p.Plot, 0.1)
+
+	err := p.Save(6*vg.Inch, -1, "testdata/h1d_fill_colors.png")
+	if err != nil {
+		log.Fatalf("error saving plot: %v\n", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleLabel shows how to stamp text labels on a plot, at positions
+// given in data or normalized coordinates.
+func ExampleLabel() {
+	const npoints = 10000
+
+	dist := distuv.Normal{Mu: 0, Sigma: 1, Src: rand.New(rand.NewSource(0))}
+	h := hbook.NewH1D(20, -4, 4)
+	for i := 0; i < npoints; i++ {
+		h.Fill(dist.Rand(), 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Labels"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Entries"
+
+	p.Add(
+		hplot.NewH1D(h),
+		hplot.NewLabel(0.02, 0.95, "Go-HEP Internal",
+			hplot.WithLabelNormalized(true),
+			hplot.WithLabelAlignment(draw.XLeft, draw.YTop),
+		),
+		hplot.NewLabel(0.98, 0.95, "√s = 13 TeV",
+			hplot.WithLabelNormalized(true),
+			hplot.WithLabelAlignment(draw.XRight, draw.YTop),
+		),
+		hplot.NewLabel(-3.5, 800, "data coordinates",
+			hplot.WithLabelAlignment(draw.XLeft, draw.YCenter),
+		),
+	)
+
+	err := p.Save(15*vg.Centimeter, 10*vg.Centimeter, "testdata/label.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
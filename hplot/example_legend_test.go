@@ -0,0 +1,71 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleLegend draws a key for several overlaid plotters.
+func ExampleLegend() {
+	const npoints = 10000
+
+	// Create two normal distributions.
+	src := rand.New(rand.NewSource(0))
+	dist1 := distuv.Normal{Mu: -1, Sigma: 1, Src: src}
+	dist2 := distuv.Normal{Mu: +1, Sigma: 0.5, Src: src}
+
+	h1 := hbook.NewH1D(40, -4, 4)
+	h2 := hbook.NewH1D(40, -4, 4)
+	for i := 0; i < npoints; i++ {
+		h1.Fill(dist1.Rand(), 1)
+		h2.Fill(dist2.Rand(), 0.5)
+	}
+
+	var (
+		top = make(plotter.XYs, 0, h1.Len())
+		bot = make(plotter.XYs, 0, h1.Len())
+	)
+	for i, bin := range h1.Binning.Bins {
+		x, y := h1.XY(i)
+		err := bin.ErrW()
+		top = append(top, plotter.XY{X: x, Y: y + err})
+		bot = append(bot, plotter.XY{X: x, Y: y - err})
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Legend"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Entries"
+
+	hp1 := hplot.NewH1D(h1)
+	hp1.LineStyle.Color = color.RGBA{B: 255, A: 255}
+
+	hp2 := hplot.NewH1D(h2)
+	hp2.LineStyle.Color = color.RGBA{R: 255, A: 255}
+	hp2.FillColor = color.RGBA{R: 255, G: 200, B: 200, A: 255}
+
+	band := hplot.NewBand(color.Gray{200}, top, bot)
+
+	legend := hplot.NewLegend()
+	legend.Add("narrow signal", hp2)
+	legend.Add("background", hp1)
+	legend.Add("background uncertainty", band)
+
+	p.Add(band, hp2, hp1, legend)
+
+	err := p.Save(15*vg.Centimeter, 10*vg.Centimeter, "testdata/legend.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleNewP1D draws a profile histogram, with some empty bins.
+func ExampleNewP1D() {
+	const npoints = 10000
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	p1d := hbook.NewP1D(20, -5, 5)
+	for i := 0; i < npoints; i++ {
+		x := dist.Rand() * 2
+		if -1 < x && x < 1 {
+			// leave a hole in the profile.
+			continue
+		}
+		y := x*x + dist.Rand()
+		p1d.Fill(x, y, 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Profile-1D"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "<Y>"
+	p.Add(plotter.NewGrid())
+
+	s := hplot.NewP1D(p1d)
+	s.GlyphStyle.Color = color.RGBA{R: 255, A: 255}
+	s.GlyphStyle.Radius = vg.Points(2)
+	p.Add(s)
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/p1d.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
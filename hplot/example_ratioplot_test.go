@@ -14,6 +14,7 @@ import (
 	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/stat/distuv"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 )
 
 func ExampleRatioPlot() {
@@ -81,3 +82,61 @@ func ExampleRatioPlot() {
 		log.Fatalf("error: %v\n", err)
 	}
 }
+
+// An example of a ratio-plot displaying the residuals of a histogram
+// with respect to a reference function.
+func ExampleRatioPlot_residuals() {
+	const npoints = 10000
+
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	hist := hbook.NewH1D(20, -4, +4)
+	for i := 0; i < npoints; i++ {
+		hist.Fill(dist.Rand(), 1)
+	}
+
+	// expected number of entries per bin.
+	bw := hist.Binning.Bins[0].XWidth()
+	fit := func(x float64) float64 {
+		return npoints * bw * distuv.UnitNormal.Prob(x)
+	}
+
+	rp := hplot.NewRatioPlot()
+	rp.Ratio = 0.3
+
+	rp.Top.Title.Text = "Residuals"
+	rp.Top.Y.Label.Text = "Entries"
+
+	h := hplot.NewH1D(hist)
+	h.FillColor = color.NRGBA{B: 255, A: 100}
+	rp.Top.Add(h)
+
+	f := hplot.NewFunction(fit)
+	f.Color = color.NRGBA{R: 255, A: 255}
+	f.Samples = 100
+	rp.Top.Add(f)
+	rp.Top.Add(hplot.NewGrid())
+
+	res := hplot.NewS2D(hbook.ResidualsH1D(hist, fit), hplot.WithXErrBars(true), hplot.WithYErrBars(true))
+	res.GlyphStyle.Shape = draw.CircleGlyph{}
+
+	rp.Bottom.X.Label.Text = "X"
+	rp.Bottom.Y.Label.Text = "Data-Fit"
+	rp.Bottom.Add(res)
+	rp.Bottom.Add(hplot.HLine(0, nil, nil))
+	rp.Bottom.Add(hplot.NewGrid())
+
+	const (
+		width  = 15 * vg.Centimeter
+		height = width / math.Phi
+	)
+
+	err := hplot.Save(rp, width, height, "testdata/residuals_plot.png")
+	if err != nil {
+		log.Fatalf("error: %v\n", err)
+	}
+}
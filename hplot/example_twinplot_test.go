@@ -0,0 +1,71 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// An example of overlaying a histogram and a cut efficiency, each with
+// its own Y axis.
+func ExampleTwinPlot() {
+	const npoints = 10000
+
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	hist := hbook.NewH1D(20, -4, +4)
+	for i := 0; i < npoints; i++ {
+		hist.Fill(dist.Rand(), 1)
+	}
+
+	// efficiency of a x > cut selection, as a function of the cut.
+	eff := make(plotter.XYs, hist.Len())
+	for i := range eff {
+		var n float64
+		for j := i; j < hist.Len(); j++ {
+			n += hist.Value(j)
+		}
+		eff[i].X = hist.Binning.Bins[i].XMin()
+		eff[i].Y = n / hist.SumW()
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Signal region"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "Entries"
+
+	h := hplot.NewH1D(hist)
+	h.FillColor = color.NRGBA{B: 255, A: 100}
+	p.Add(h)
+
+	line, err := plotter.NewLine(eff)
+	if err != nil {
+		log.Fatalf("could not create efficiency line: %+v", err)
+	}
+	line.Color = color.NRGBA{R: 255, A: 255}
+	line.Width = vg.Points(2)
+
+	tp := hplot.NewTwinPlot(p, line)
+	tp.Y2.Label.Text = "Efficiency (x > cut)"
+	tp.Y2.Min = 0
+	tp.Y2.Max = 1.1
+
+	err = tp.Save(15*vg.Centimeter, -1, "testdata/twin_plot.png")
+	if err != nil {
+		log.Fatalf("could not save twin plot: %+v", err)
+	}
+}
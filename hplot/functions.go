@@ -12,7 +12,6 @@ import (
 	"math"
 
 	"gonum.org/v1/plot"
-	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -34,6 +33,8 @@ type Function struct {
 	// When enabled, function values returning 0 will be discarded from
 	// the final plot.
 	LogY bool
+
+	style *Style // style active when the function was created
 }
 
 // NewFunction returns a Function that plots F using
@@ -42,7 +43,8 @@ func NewFunction(f func(float64) float64) *Function {
 	return &Function{
 		F:         f,
 		Samples:   50,
-		LineStyle: plotter.DefaultLineStyle,
+		LineStyle: DefaultStyle.LineStyle,
+		style:     activeStyle(),
 	}
 }
 
@@ -50,6 +52,7 @@ func NewFunction(f func(float64) float64) *Function {
 // that connects each point in the Line.
 func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
+	sty := activeLineStyle(f.LineStyle, f.style)
 
 	min, max := f.XMin, f.XMax
 	if min == 0 && max == 0 {
@@ -82,7 +85,7 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 				// FIXME(sbinet): we should find a couple of points around...
 				continue
 			}
-			c.StrokeLines(f.LineStyle, c.ClipLinesXY(line)...)
+			c.StrokeLines(sty, c.ClipLinesXY(line)...)
 		}
 	default:
 		line := make([]vg.Point, f.Samples)
@@ -92,7 +95,7 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 			line[i].X = trX(x)
 			line[i].Y = trY(y)
 		}
-		c.StrokeLines(f.LineStyle, c.ClipLinesXY(line)...)
+		c.StrokeLines(sty, c.ClipLinesXY(line)...)
 	}
 }
 
@@ -101,5 +104,5 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 // of the LineStyle of the function.
 func (f Function) Thumbnail(c *draw.Canvas) {
 	y := c.Center().Y
-	c.StrokeLine2(f.LineStyle, c.Min.X, y, c.Max.X, y)
+	c.StrokeLine2(activeLineStyle(f.LineStyle, f.style), c.Min.X, y, c.Max.X, y)
 }
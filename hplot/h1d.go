@@ -28,6 +28,14 @@ type H1D struct {
 	// then the bars are not filled.
 	FillColor color.Color
 
+	// FillColors are the colors used to fill each bar
+	// of the histogram, indexed by bin.
+	// When FillColors is set, each bin is filled with its
+	// own rectangle, using FillColors[i] if it is non-nil
+	// and FillColor otherwise.
+	// Bins without a color are not filled.
+	FillColors []color.Color
+
 	// LineStyle is the style of the outline of each
 	// bar of the histogram.
 	draw.LineStyle
@@ -36,6 +44,14 @@ type H1D struct {
 	// at the top of each histogram bar.
 	GlyphStyle draw.GlyphStyle
 
+	// DrawMode selects how the bins are drawn:
+	// as a steps outline (the default), as horizontal
+	// segments centered on the bin centers or as points.
+	// FillColor is ignored when bins are drawn as points.
+	// Points use GlyphStyle, or the glyph style of DefaultStyle
+	// in the color of LineStyle if GlyphStyle has no radius.
+	DrawMode DrawMode
+
 	// LogY allows rendering with a log-scaled Y axis.
 	// When enabled, histogram bins with no entries will be discarded from
 	// the histogram's DataRange.
@@ -44,15 +60,33 @@ type H1D struct {
 	// on the final plot.
 	LogY bool
 
+	// LogYFloorFrac is the fraction of the smallest non-zero bin
+	// content used as the lowest Y value of the DataRange, when LogY
+	// is enabled and some bins are empty.
+	// Smaller values leave more room below the smallest bin.
+	// The default value is 0.5.
+	LogYFloorFrac float64
+
 	// InfoStyle is the style of infos displayed for
 	// the histogram (entries, mean, rms)
 	Infos HInfos
 
+	// ShowValues displays the content of each non-empty bin
+	// above the bin, using the tick font of the default style.
+	ShowValues bool
+
+	// ValuesFormat is the fmt format used to display the
+	// bins contents when ShowValues is enabled.
+	// The default format is "%g".
+	ValuesFormat string
+
 	// YErrs is the y error bars plotter.
 	YErrs *plotter.YErrorBars
 
 	// Band displays a colored band between the y-min and y-max error bars.
 	Band *Band
+
+	style *Style // style active when the histogram was created
 }
 
 type HInfoStyle uint32
@@ -63,6 +97,7 @@ const (
 	HInfoMean
 	HInfoRMS
 	HInfoStdDev
+	HInfoEffEntries
 	HInfoSummary HInfoStyle = HInfoEntries | HInfoMean | HInfoStdDev
 )
 
@@ -107,12 +142,14 @@ func (u unitYs) XY(i int) (float64, float64) {
 func NewH1D(h *hbook.H1D, opts ...Options) *H1D {
 	h1 := &H1D{
 		Hist:      h,
-		LineStyle: plotter.DefaultLineStyle,
+		LineStyle: DefaultStyle.LineStyle,
+		style:     activeStyle(),
 	}
 
 	cfg := newConfig(opts)
 
 	h1.LogY = cfg.log.y
+	h1.DrawMode = cfg.mode
 	h1.Infos = cfg.hinfos
 
 	if cfg.band {
@@ -130,6 +167,21 @@ func NewH1D(h *hbook.H1D, opts ...Options) *H1D {
 	return h1
 }
 
+// SetFill fills the histogram with a translucent version of the color c,
+// using alpha as its opacity, and draws the outline with the opaque color c.
+//
+// alpha is clamped to the [0,1] range.
+func (h *H1D) SetFill(c color.Color, alpha float64) {
+	alpha = math.Max(0, math.Min(1, alpha))
+	line := color.NRGBAModel.Convert(c).(color.NRGBA)
+	line.A = 255
+	fill := line
+	fill.A = uint8(math.Round(alpha * 255))
+
+	h.FillColor = fill
+	h.LineStyle.Color = line
+}
+
 // withYErrBars enables the Y error bars
 func (h *H1D) withYErrBars(yoffs []float64) *plotter.YErrorBars {
 	bins := h.Hist.Binning.Bins
@@ -227,7 +279,7 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 
 	if ymin == 0 && !math.IsInf(ylow, +1) {
 		// Reserve a bit of space for the smallest bin to be displayed still.
-		ymin = ylow * 0.5
+		ymin = logYFloor(ylow, h.LogYFloorFrac)
 	}
 
 	if h.YErrs != nil {
@@ -241,36 +293,34 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return
 }
 
-// Plot implements the Plotter interface, drawing a line
-// that connects each point in the Line.
-func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
-	trX, trY := p.Transforms(&c)
-	var pts []vg.Point
-	hist := h.Hist
-	bins := h.Hist.Binning.Bins
-	nbins := len(bins)
-
-	yfct := func(sumw float64) (ymin, ymax vg.Length) {
-		return trY(0), trY(sumw)
-	}
-	if h.LogY {
-		yfct = func(sumw float64) (ymin, ymax vg.Length) {
-			ymin = c.Min.Y
-			ymax = c.Min.Y
-			if 0 != sumw {
-				ymax = trY(sumw)
-			}
-			return ymin, ymax
-		}
+// logYFloor returns the lowest Y value of a log-scaled DataRange,
+// given the smallest non-zero bin content ylow.
+func logYFloor(ylow, frac float64) float64 {
+	if frac <= 0 {
+		frac = 0.5
 	}
+	return ylow * frac
+}
 
-	var glyphs []vg.Point
+// Outline returns the polygon outlining the bins of the histogram, as
+// drawn by Plot in the Steps draw mode, in the coordinates of the canvas c.
+//
+// The polygon starts at the bottom-left corner of the first bin and ends
+// at the bottom-right corner of the last bin.
+// The bottom of the bins is the bottom of the canvas when LogY is enabled.
+func (h *H1D) Outline(p *plot.Plot, c draw.Canvas) []vg.Point {
+	var (
+		trX, trY = p.Transforms(&c)
+		yfct     = h.yfunc(c, trY)
+		bins     = h.Hist.Binning.Bins
+		nbins    = len(bins)
+		pts      = make([]vg.Point, 0, 3*nbins+1)
+	)
 
 	for i, bin := range bins {
 		xmin := trX(bin.XMin())
 		xmax := trX(bin.XMax())
-		sumw := bin.SumW()
-		ymin, ymax := yfct(sumw)
+		ymin, ymax := yfct(bin.SumW())
 		switch i {
 		case 0:
 			pts = append(pts, vg.Point{X: xmin, Y: ymin})
@@ -294,8 +344,52 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 			pts = append(pts, vg.Point{X: xmin, Y: ymax})
 			pts = append(pts, vg.Point{X: xmax, Y: ymax})
 		}
+	}
+
+	return pts
+}
 
-		if h.GlyphStyle.Radius != 0 {
+// yfunc returns the function computing the bottom and top of a bin
+// with the given sum of weights, in the coordinates of the canvas c.
+func (h *H1D) yfunc(c draw.Canvas, trY func(float64) vg.Length) func(sumw float64) (ymin, ymax vg.Length) {
+	if h.LogY {
+		return func(sumw float64) (ymin, ymax vg.Length) {
+			ymin = c.Min.Y
+			ymax = c.Min.Y
+			if 0 != sumw {
+				ymax = trY(sumw)
+			}
+			return ymin, ymax
+		}
+	}
+	return func(sumw float64) (ymin, ymax vg.Length) {
+		return trY(0), trY(sumw)
+	}
+}
+
+// Plot implements the Plotter interface, drawing a line
+// that connects each point in the Line.
+func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, trY := p.Transforms(&c)
+	hist := h.Hist
+	bins := h.Hist.Binning.Bins
+	yfct := h.yfunc(c, trY)
+	pts := h.Outline(p, c)
+
+	var (
+		glyphs []vg.Point
+		segs   [][]vg.Point
+	)
+
+	for _, bin := range bins {
+		if h.DrawMode == StepsMid {
+			xmin := trX(bin.XMin())
+			xmax := trX(bin.XMax())
+			_, ymax := yfct(bin.SumW())
+			segs = append(segs, []vg.Point{{X: xmin, Y: ymax}, {X: xmax, Y: ymax}})
+		}
+
+		if h.GlyphStyle.Radius != 0 || h.DrawMode == Points {
 			x := trX(bin.XMid())
 			_, y := yfct(bin.SumW())
 			// capture glyph location, to be drawn after
@@ -304,7 +398,30 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 		}
 	}
 
-	if h.FillColor != nil {
+	switch {
+	case h.DrawMode == Points:
+		// bins are only drawn as glyphs.
+	case h.FillColors != nil:
+		for i, bin := range bins {
+			col := h.FillColor
+			if i < len(h.FillColors) && h.FillColors[i] != nil {
+				col = h.FillColors[i]
+			}
+			if col == nil {
+				continue
+			}
+			xmin := trX(bin.XMin())
+			xmax := trX(bin.XMax())
+			ymin, ymax := yfct(bin.SumW())
+			rect := []vg.Point{
+				{X: xmin, Y: ymin},
+				{X: xmin, Y: ymax},
+				{X: xmax, Y: ymax},
+				{X: xmax, Y: ymin},
+			}
+			c.FillPolygon(col, c.ClipPolygonXY(rect))
+		}
+	case h.FillColor != nil:
 		c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
 	}
 
@@ -312,15 +429,43 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 		h.Band.Plot(c, p)
 	}
 
-	c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
+	switch h.DrawMode {
+	case StepsMid:
+		c.StrokeLines(h.lineStyle(), c.ClipLinesXY(segs...)...)
+	case Points:
+		// bins are only drawn as glyphs.
+	default:
+		c.StrokeLines(h.lineStyle(), c.ClipLinesXY(pts)...)
+	}
 
 	if h.YErrs != nil {
 		h.YErrs.Plot(c, p)
 	}
 
-	if h.GlyphStyle.Radius != 0 {
+	if len(glyphs) > 0 {
+		sty := h.glyphStyle()
 		for _, glyph := range glyphs {
-			c.DrawGlyph(h.GlyphStyle, glyph)
+			c.DrawGlyph(sty, glyph)
+		}
+	}
+
+	if h.ShowValues {
+		format := h.ValuesFormat
+		if format == "" {
+			format = "%g"
+		}
+		sty := draw.TextStyle{Font: DefaultStyle.Fonts.Tick, XAlign: draw.XCenter}
+		pad := vg.Points(2) // gap between the bin top and its value
+		for _, bin := range bins {
+			if bin.Entries() == 0 {
+				continue
+			}
+			_, y := yfct(bin.SumW())
+			pt := vg.Point{X: trX(bin.XMid()), Y: y + pad}
+			if !c.Contains(pt) {
+				continue
+			}
+			c.FillText(sty, pt, fmt.Sprintf(format, bin.SumW()))
 		}
 	}
 
@@ -343,6 +488,8 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 					legend.Add("RMS", hist.XRMS())
 				case HInfoStdDev:
 					legend.Add("Std Dev", hist.XStdDev())
+				case HInfoEffEntries:
+					legend.Add("Eff. Entries", hist.EffEntries())
 				default:
 				}
 			}
@@ -353,6 +500,24 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 	}
 }
 
+// lineStyle returns the style of the outline of the bins,
+// following the active style.
+func (h *H1D) lineStyle() draw.LineStyle {
+	return activeLineStyle(h.LineStyle, h.style)
+}
+
+// glyphStyle returns the style of the glyphs drawn at the bin centers.
+func (h *H1D) glyphStyle() draw.GlyphStyle {
+	if h.GlyphStyle.Radius != 0 || h.DrawMode != Points {
+		return h.GlyphStyle
+	}
+	sty := DefaultStyle.GlyphStyle
+	if line := h.lineStyle(); line.Color != nil {
+		sty.Color = line.Color
+	}
+	return sty
+}
+
 // GlyphBoxes returns a slice of GlyphBoxes,
 // one for each of the bins, implementing the
 // plot.GlyphBoxer interface.
@@ -402,7 +567,7 @@ func (h *H1D) Thumbnail(c *draw.Canvas) {
 	xmin := c.Min.X
 	xmax := c.Max.X
 
-	if h.FillColor != nil {
+	if h.FillColor != nil && h.DrawMode != Points {
 		pts := []vg.Point{
 			{X: xmin, Y: ymin},
 			{X: xmax, Y: ymin},
@@ -412,14 +577,14 @@ func (h *H1D) Thumbnail(c *draw.Canvas) {
 		}
 		c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
 	}
-	if h.LineStyle.Width != 0 {
+	if sty := h.lineStyle(); sty.Width != 0 && h.DrawMode != Points {
 		ymid := c.Center().Y
 		line := []vg.Point{{X: xmin, Y: ymid}, {X: xmax, Y: ymid}}
-		c.StrokeLines(h.LineStyle, c.ClipLinesX(line)...)
+		c.StrokeLines(sty, c.ClipLinesX(line)...)
 	}
 
-	if h.GlyphStyle != (draw.GlyphStyle{}) {
-		c.DrawGlyph(h.GlyphStyle, c.Center())
+	if h.GlyphStyle != (draw.GlyphStyle{}) || h.DrawMode == Points {
+		c.DrawGlyph(h.glyphStyle(), c.Center())
 		if h.YErrs != nil {
 			var (
 				yerrs = h.YErrs
@@ -14,6 +14,7 @@ import (
 	"go-hep.org/x/hep/hplot"
 	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/cmpimg"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -44,6 +45,18 @@ func TestH1DAsData(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleH1D_withYErrBarsAndData, t, "h1d_glyphs.png")
 }
 
+func TestH1DDrawMode(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH1D_drawMode, t, "h1d_draw_mode.png")
+}
+
+func TestH1DWithValues(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH1D_withValues, t, "h1d_values.png")
+}
+
+func TestH1DWithFillColors(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH1D_withFillColors, t, "h1d_fill_colors.png")
+}
+
 func TestH1DWithBorders(t *testing.T) {
 	_ = os.Remove("testdata/h1d_borders.png")
 	checkPlot(cmpimg.CheckPlot)(ExampleH1D_withPlotBorders, t, "h1d_borders.png")
@@ -127,3 +140,135 @@ func TestH1DWithBorders(t *testing.T) {
 		}
 	}, t, "h1d_borders.png")
 }
+
+func TestH1DLogYFloorFrac(t *testing.T) {
+	h := hbook.NewH1D(4, 0, 4)
+	h.Fill(1, 10)
+	h.Fill(2, 100)
+	h.Fill(3, 1000)
+
+	for _, tc := range []struct {
+		frac float64
+		want float64
+	}{
+		{0, 5},
+		{0.5, 5},
+		{1e-3, 1e-2},
+	} {
+		h1 := hplot.NewH1D(h, hplot.WithLogY(true))
+		h1.LogYFloorFrac = tc.frac
+		_, _, ymin, ymax := h1.DataRange()
+		if got, want := ymin, tc.want; math.Abs(got-want) > 1e-12 {
+			t.Errorf("frac=%v: invalid h1d ymin: got=%v, want=%v", tc.frac, got, want)
+		}
+		if got, want := ymax, 1000.0; got != want {
+			t.Errorf("frac=%v: invalid h1d ymax: got=%v, want=%v", tc.frac, got, want)
+		}
+
+		hs := hplot.NewHStack([]*hplot.H1D{h1}, hplot.WithLogY(true))
+		hs.LogYFloorFrac = tc.frac
+		_, _, ymin, _ = hs.DataRange()
+		if got, want := ymin, tc.want; math.Abs(got-want) > 1e-12 {
+			t.Errorf("frac=%v: invalid hstack ymin: got=%v, want=%v", tc.frac, got, want)
+		}
+	}
+}
+
+func TestH1DOutline(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		logy bool
+		ws   [2]float64
+		want []vg.Point
+	}{
+		{
+			name: "linear",
+			ws:   [2]float64{1, 2},
+			want: []vg.Point{
+				{X: 0, Y: 0}, {X: 0, Y: 50}, {X: 50, Y: 50},
+				{X: 50, Y: 50}, {X: 50, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 0},
+			},
+		},
+		{
+			name: "logy",
+			logy: true,
+			ws:   [2]float64{10, 100},
+			want: []vg.Point{
+				{X: 0, Y: 0}, {X: 0, Y: 50}, {X: 50, Y: 50},
+				{X: 50, Y: 50}, {X: 50, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 0},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hist := hbook.NewH1D(2, 0, 2)
+			hist.Fill(0.5, tc.ws[0])
+			hist.Fill(1.5, tc.ws[1])
+
+			p := hplot.New()
+			p.X.Min = 0
+			p.X.Max = 2
+			p.Y.Min = 0
+			p.Y.Max = 2
+			if tc.logy {
+				p.Y.Scale = plot.LogScale{}
+				p.Y.Min = 1
+				p.Y.Max = 100
+			}
+
+			h := hplot.NewH1D(hist, hplot.WithLogY(tc.logy))
+			c := draw.New(vgimg.New(100, 100))
+			got := h.Outline(p.Plot, c)
+			if len(got) != len(tc.want) {
+				t.Fatalf("invalid number of points: got=%d, want=%d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if math.Abs(float64(got[i].X-tc.want[i].X)) > 1e-9 || math.Abs(float64(got[i].Y-tc.want[i].Y)) > 1e-9 {
+					t.Fatalf("invalid point %d: got=%v, want=%v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestH1DSetFill(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		c     color.Color
+		alpha float64
+		fill  color.NRGBA
+		line  color.NRGBA
+	}{
+		{
+			name:  "opaque",
+			c:     color.NRGBA{R: 255, G: 128, B: 0, A: 255},
+			alpha: 0.5,
+			fill:  color.NRGBA{R: 255, G: 128, B: 0, A: 128},
+			line:  color.NRGBA{R: 255, G: 128, B: 0, A: 255},
+		},
+		{
+			name:  "translucent",
+			c:     color.RGBA{R: 0, G: 0, B: 128, A: 128},
+			alpha: 0.2,
+			fill:  color.NRGBA{R: 0, G: 0, B: 255, A: 51},
+			line:  color.NRGBA{R: 0, G: 0, B: 255, A: 255},
+		},
+		{
+			name:  "clamped",
+			c:     color.Black,
+			alpha: 2,
+			fill:  color.NRGBA{A: 255},
+			line:  color.NRGBA{A: 255},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := hplot.NewH1D(hbook.NewH1D(10, 0, 1))
+			h.SetFill(tc.c, tc.alpha)
+			if got, want := h.FillColor, tc.fill; got != want {
+				t.Errorf("invalid fill color: got=%v, want=%v", got, want)
+			}
+			if got, want := h.LineStyle.Color, tc.line; got != want {
+				t.Errorf("invalid line color: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
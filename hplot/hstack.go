@@ -27,6 +27,12 @@ type HStack struct {
 	// on the final plot.
 	LogY bool
 
+	// LogYFloorFrac is the fraction of the smallest non-zero bin
+	// content used as the lowest Y value of the DataRange, when LogY
+	// is enabled and some bins are empty.
+	// The default value is 0.5.
+	LogYFloorFrac float64
+
 	// Stack specifies how histograms are displayed.
 	// Default is to display histograms stacked on top of each other.
 	// If not stacked, individual histogram uncertainty bands will be
@@ -131,7 +137,7 @@ func (hstack *HStack) DataRange() (xmin, xmax, ymin, ymax float64) {
 	if hstack.LogY {
 		if ymin == 0 && !math.IsInf(ylow, +1) {
 			// Reserve a bit of space for the smallest bin to be displayed still.
-			ymin = ylow * 0.5
+			ymin = logYFloor(ylow, hstack.LogYFloorFrac)
 		}
 	}
 
@@ -289,6 +295,8 @@ func (hs *HStack) hplot(c draw.Canvas, p *plot.Plot, h *H1D, yoffs []float64, hs
 					legend.Add("RMS", hist.XRMS())
 				case HInfoStdDev:
 					legend.Add("Std Dev", hist.XStdDev())
+				case HInfoEffEntries:
+					legend.Add("Eff. Entries", hist.EffEntries())
 				default:
 				}
 			}
@@ -0,0 +1,115 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Label displays a user-defined text string on a plot.
+//
+// The position of the label is given in data coordinates, unless
+// Normalized is true.
+// Normalized coordinates are fractions of the data area, where (0,0) is
+// its lower left corner and (1,1) its upper right corner: normalized labels
+// stay put whatever the range of the data.
+type Label struct {
+	Text      string         // Text of the label
+	X, Y      float64        // Position of the label
+	TextStyle draw.TextStyle // Text style of the label, including its alignment
+
+	// Normalized indicates whether the position of the label is
+	// given in normalized coordinates of the data area,
+	// or in data coordinates.
+	Normalized bool
+}
+
+// LabelOption configures a Label.
+type LabelOption func(lbl *Label)
+
+// WithLabelTextStyle sets the text style of a label.
+func WithLabelTextStyle(sty draw.TextStyle) LabelOption {
+	return func(lbl *Label) {
+		lbl.TextStyle = sty
+	}
+}
+
+// WithLabelAlignment sets the alignment of a label with regard to
+// its position.
+func WithLabelAlignment(x draw.XAlignment, y draw.YAlignment) LabelOption {
+	return func(lbl *Label) {
+		lbl.TextStyle.XAlign = x
+		lbl.TextStyle.YAlign = y
+	}
+}
+
+// WithLabelNormalized sets whether the position of a label is given in
+// normalized coordinates of the data area.
+func WithLabelNormalized(v bool) LabelOption {
+	return func(lbl *Label) {
+		lbl.Normalized = v
+	}
+}
+
+// NewLabel returns a new label displaying txt at position (x, y),
+// using the default legend font.
+//
+// NewLabel panics if the label is normalized and x or y are
+// outside of [0, 1].
+func NewLabel(x, y float64, txt string, opts ...LabelOption) *Label {
+	lbl := &Label{
+		Text:      txt,
+		X:         x,
+		Y:         y,
+		TextStyle: draw.TextStyle{Font: DefaultStyle.Fonts.Legend},
+	}
+	for _, opt := range opts {
+		opt(lbl)
+	}
+
+	if lbl.Normalized && !(0 <= x && x <= 1 && 0 <= y && y <= 1) {
+		panic(fmt.Errorf("hplot: normalized label position (%v, %v) outside of [0, 1]", x, y))
+	}
+
+	return lbl
+}
+
+// Plot implements the plot.Plotter interface.
+func (lbl *Label) Plot(c draw.Canvas, p *plot.Plot) {
+	c.FillText(lbl.TextStyle, lbl.position(c, p), lbl.Text)
+}
+
+// DataRange returns the minimum and maximum X and Y values,
+// implementing the plot.DataRanger interface.
+// Normalized labels do not contribute to the range of the data.
+func (lbl *Label) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if lbl.Normalized {
+		inf := math.Inf(+1)
+		return inf, -inf, inf, -inf
+	}
+	return lbl.X, lbl.X, lbl.Y, lbl.Y
+}
+
+// position returns the position of the label on the canvas.
+func (lbl *Label) position(c draw.Canvas, p *plot.Plot) vg.Point {
+	if lbl.Normalized {
+		return vg.Point{
+			X: c.Min.X + vg.Length(lbl.X)*(c.Max.X-c.Min.X),
+			Y: c.Min.Y + vg.Length(lbl.Y)*(c.Max.Y-c.Min.Y),
+		}
+	}
+	trX, trY := p.Transforms(&c)
+	return vg.Point{X: trX(lbl.X), Y: trY(lbl.Y)}
+}
+
+var (
+	_ plot.Plotter    = (*Label)(nil)
+	_ plot.DataRanger = (*Label)(nil)
+)
@@ -0,0 +1,43 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestLabel(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleLabel, t, "label.png")
+}
+
+func TestLabelDataRange(t *testing.T) {
+	lbl := hplot.NewLabel(2, 3, "data")
+	xmin, xmax, ymin, ymax := lbl.DataRange()
+	if xmin != 2 || xmax != 2 || ymin != 3 || ymax != 3 {
+		t.Fatalf("invalid data range: (%v, %v, %v, %v)", xmin, xmax, ymin, ymax)
+	}
+
+	p := hplot.New()
+	p.X.Min, p.X.Max = -1, 1
+	p.Y.Min, p.Y.Max = -1, 1
+	p.Add(hplot.NewLabel(0.5, 0.5, "normalized", hplot.WithLabelNormalized(true)))
+	if p.X.Min != -1 || p.X.Max != 1 || p.Y.Min != -1 || p.Y.Max != 1 {
+		t.Fatalf("normalized label modified the data range: x=[%v, %v], y=[%v, %v]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max,
+		)
+	}
+}
+
+func TestLabelNormalizedPanics(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	_ = hplot.NewLabel(1.5, 0.5, "out", hplot.WithLabelNormalized(true))
+}
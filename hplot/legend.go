@@ -0,0 +1,193 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Legend is a plot.Plotter drawing a key for the data elements of a plot,
+// inside the data area of the plot.
+//
+// Each legend entry has a name and a thumbnail, drawn from the Thumbnail
+// method of the plotters registered with that entry (e.g. H1D, S2D, Band.)
+// The legend is sized after its longest entry name.
+type Legend struct {
+	// TextStyle is the style given to the legend entry texts.
+	draw.TextStyle
+
+	// Padding is the amount of padding to add between each entry
+	// of the legend.
+	Padding vg.Length
+
+	// Top and Left specify the location of the legend.
+	// If Top is true the legend is located along the top
+	// edge of the data area, otherwise it is located along
+	// the bottom edge. If Left is true then the legend
+	// is located along the left edge of the data area, and the
+	// text is positioned after the thumbnails, otherwise it is
+	// located along the right edge and the text is
+	// positioned before the thumbnails.
+	Top, Left bool
+
+	// XOffs and YOffs are added to the legend's final position.
+	XOffs, YOffs vg.Length
+
+	// ThumbnailWidth is the width of legend thumbnails.
+	ThumbnailWidth vg.Length
+
+	// BoxStyle is the style of the box drawn around the legend.
+	// Use zero width to disable.
+	BoxStyle draw.LineStyle
+
+	entries []legendItem
+	style   *Style // style active when the legend was created
+}
+
+// legendItem is an entry of a Legend.
+type legendItem struct {
+	text   string
+	thumbs []plot.Thumbnailer
+}
+
+// NewLegend returns a legend located at the top right of the data area,
+// using the current default style.
+func NewLegend() *Legend {
+	return &Legend{
+		TextStyle:      draw.TextStyle{Font: DefaultStyle.Fonts.Legend},
+		Top:            true,
+		ThumbnailWidth: vg.Points(20),
+		BoxStyle:       DefaultStyle.LineStyle,
+		style:          activeStyle(),
+	}
+}
+
+// Add adds an entry to the legend with the given name.
+// The entry's thumbnail is drawn as the composite of all of the
+// thumbnails.
+func (l *Legend) Add(name string, thumbs ...plot.Thumbnailer) {
+	l.entries = append(l.entries, legendItem{text: name, thumbs: thumbs})
+}
+
+// Plot draws the legend on the data area of the plot,
+// implementing the plot.Plotter interface.
+func (l *Legend) Plot(c draw.Canvas, plt *plot.Plot) {
+	if len(l.entries) == 0 {
+		return
+	}
+
+	var (
+		sty    = l.textStyle()
+		space  = sty.Width(" ")
+		enth   = l.entryHeight(sty)
+		margin = enth / 2
+		width  = l.ThumbnailWidth + space + l.entryWidth(sty)
+		r      = l.rectangle(c, width, enth, margin)
+	)
+
+	iconx := r.Min.X + margin
+	textx := iconx + l.ThumbnailWidth + space
+	sty.XAlign = draw.XLeft
+	if !l.Left {
+		iconx = r.Max.X - margin - l.ThumbnailWidth
+		textx = iconx - space
+		sty.XAlign = draw.XRight
+	}
+
+	icon := &draw.Canvas{
+		Canvas: c.Canvas,
+		Rectangle: vg.Rectangle{
+			Min: vg.Point{X: iconx, Y: r.Max.Y - margin - enth},
+			Max: vg.Point{X: iconx + l.ThumbnailWidth, Y: r.Max.Y - margin},
+		},
+	}
+	for _, e := range l.entries {
+		for _, t := range e.thumbs {
+			t.Thumbnail(icon)
+		}
+		yoffs := (enth - sty.Height(e.text)) / 2
+		c.FillText(sty, vg.Point{X: textx, Y: icon.Min.Y + yoffs}, e.text)
+		icon.Min.Y -= enth + l.Padding
+		icon.Max.Y -= enth + l.Padding
+	}
+
+	if box := l.boxStyle(); box.Width != 0 {
+		c.StrokeLines(box, []vg.Point{
+			{X: r.Min.X, Y: r.Min.Y},
+			{X: r.Max.X, Y: r.Min.Y},
+			{X: r.Max.X, Y: r.Max.Y},
+			{X: r.Min.X, Y: r.Max.Y},
+			{X: r.Min.X, Y: r.Min.Y},
+		})
+	}
+}
+
+// textStyle returns the style of the entry texts, following the active style.
+func (l *Legend) textStyle() draw.TextStyle {
+	sty := l.TextStyle
+	if l.style != nil {
+		sty.Font = activeFont(sty.Font, l.style.Fonts.Legend, DefaultStyle.Fonts.Legend)
+	}
+	return sty
+}
+
+// boxStyle returns the style of the box, following the active style.
+func (l *Legend) boxStyle() draw.LineStyle {
+	return activeLineStyle(l.BoxStyle, l.style)
+}
+
+// rectangle returns the extent of the legend box, given the width of
+// its content, the height of its entries and the margin around them.
+func (l *Legend) rectangle(c draw.Canvas, width, enth, margin vg.Length) vg.Rectangle {
+	var (
+		n      = vg.Length(len(l.entries))
+		height = n*enth + (n-1)*l.Padding + 2*margin
+	)
+	width += 2 * margin
+
+	var r vg.Rectangle
+	switch {
+	case l.Left:
+		r.Min.X = c.Min.X
+	default:
+		r.Min.X = c.Max.X - width
+	}
+	switch {
+	case l.Top:
+		r.Min.Y = c.Max.Y - height
+	default:
+		r.Min.Y = c.Min.Y
+	}
+	r.Min.X += l.XOffs
+	r.Min.Y += l.YOffs
+	r.Max = vg.Point{X: r.Min.X + width, Y: r.Min.Y + height}
+	return r
+}
+
+// entryHeight returns the height of the tallest legend entry text.
+func (l *Legend) entryHeight(sty draw.TextStyle) (height vg.Length) {
+	for _, e := range l.entries {
+		if h := sty.Height(e.text); h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+// entryWidth returns the width of the largest legend entry text.
+func (l *Legend) entryWidth(sty draw.TextStyle) (width vg.Length) {
+	for _, e := range l.entries {
+		if w := sty.Width(e.text); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+var (
+	_ plot.Plotter = (*Legend)(nil)
+)
@@ -8,7 +8,6 @@ import (
 	"image/color"
 
 	"gonum.org/v1/plot"
-	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -21,15 +20,18 @@ type VertLine struct {
 	Line  draw.LineStyle
 	Left  color.Color
 	Right color.Color
+
+	style *Style // style active when the line was created
 }
 
 // VLine creates a vertical line at x with the default line style.
 func VLine(x float64, left, right color.Color) *VertLine {
 	return &VertLine{
 		X:     x,
-		Line:  plotter.DefaultLineStyle,
+		Line:  DefaultStyle.LineStyle,
 		Left:  left,
 		Right: right,
+		style: activeStyle(),
 	}
 }
 
@@ -60,8 +62,8 @@ func (vline *VertLine) Plot(c draw.Canvas, plt *plot.Plot) {
 		c.Fill(rect.Path())
 	}
 
-	if vline.Line.Width != 0 && xmin <= x && x <= xmax {
-		c.StrokeLine2(vline.Line, x, ymin, x, ymax)
+	if sty := activeLineStyle(vline.Line, vline.style); sty.Width != 0 && xmin <= x && x <= xmax {
+		c.StrokeLine2(sty, x, ymin, x, ymax)
 	}
 }
 
@@ -98,9 +100,9 @@ func (vline *VertLine) Thumbnail(c *draw.Canvas) {
 		c.FillPolygon(vline.Right, poly)
 	}
 
-	if vline.Line.Width != 0 {
+	if sty := activeLineStyle(vline.Line, vline.style); sty.Width != 0 {
 		x := c.Center().X
-		c.StrokeLine2(vline.Line, x, c.Min.Y, x, c.Max.Y)
+		c.StrokeLine2(sty, x, c.Min.Y, x, c.Max.Y)
 	}
 }
 
@@ -112,15 +114,18 @@ type HorizLine struct {
 	Line   draw.LineStyle
 	Top    color.Color
 	Bottom color.Color
+
+	style *Style // style active when the line was created
 }
 
 // HLine creates a horizontal line at y with the default line style.
 func HLine(y float64, top, bottom color.Color) *HorizLine {
 	return &HorizLine{
 		Y:      y,
-		Line:   plotter.DefaultLineStyle,
+		Line:   DefaultStyle.LineStyle,
 		Top:    top,
 		Bottom: bottom,
+		style:  activeStyle(),
 	}
 }
 
@@ -151,8 +156,8 @@ func (hline *HorizLine) Plot(c draw.Canvas, plt *plot.Plot) {
 		c.Fill(rect.Path())
 	}
 
-	if hline.Line.Width != 0 && ymin <= y && y <= ymax {
-		c.StrokeLine2(hline.Line, xmin, y, xmax, y)
+	if sty := activeLineStyle(hline.Line, hline.style); sty.Width != 0 && ymin <= y && y <= ymax {
+		c.StrokeLine2(sty, xmin, y, xmax, y)
 	}
 }
 
@@ -189,9 +194,9 @@ func (hline *HorizLine) Thumbnail(c *draw.Canvas) {
 		c.FillPolygon(hline.Bottom, poly)
 	}
 
-	if hline.Line.Width != 0 {
+	if sty := activeLineStyle(hline.Line, hline.style); sty.Width != 0 {
 		y := c.Center().Y
-		c.StrokeLine2(hline.Line, c.Min.X, y, c.Max.X, y)
+		c.StrokeLine2(sty, c.Min.X, y, c.Max.X, y)
 	}
 }
 
@@ -19,6 +19,22 @@ const (
 	HiSteps
 )
 
+// DrawMode describes how the bins of a histogram are drawn.
+type DrawMode byte
+
+const (
+	// Steps draws the bins as a connected outline, with horizontal
+	// segments spanning the bins and vertical segments at the bin edges.
+	Steps DrawMode = iota
+
+	// StepsMid draws each bin as a horizontal segment centered on the
+	// bin center, without the vertical segments joining adjacent bins.
+	StepsMid
+
+	// Points draws each bin as a glyph at the bin center.
+	Points
+)
+
 type config struct {
 	bars struct {
 		xerrs bool
@@ -31,6 +47,7 @@ type config struct {
 	}
 	glyph draw.GlyphStyle
 	steps StepsKind
+	mode  DrawMode
 }
 
 func newConfig(opts []Options) *config {
@@ -77,6 +94,13 @@ func WithStepsKind(s StepsKind) Options {
 	}
 }
 
+// WithDrawMode sets how the bins of a histogram are drawn (Steps, StepsMid or Points).
+func WithDrawMode(m DrawMode) Options {
+	return func(c *config) {
+		c.mode = m
+	}
+}
+
 // WithGlyphStyle sets the glyph style of a plotter.
 func WithGlyphStyle(sty draw.GlyphStyle) Options {
 	return func(c *config) {
@@ -0,0 +1,39 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+)
+
+// NewP1D creates a plotter for a 1-dim profile histogram.
+// Each bin is drawn as a point located at the mean Y of that bin,
+// with vertical error bars showing the standard error on that mean.
+//
+// Empty bins have no mean and are not drawn.
+// The annotations of the profile (name, title, ...) are kept on the
+// plotted scatter.
+//
+// Y-error bars are enabled by default and may be disabled with
+// WithYErrBars(false).
+func NewP1D(p *hbook.P1D, opts ...Options) *S2D {
+	opts = append([]Options{WithYErrBars(true)}, opts...)
+
+	s2d := hbook.NewS2DFromP1D(p)
+	pts := make([]hbook.Point2D, 0, s2d.Len())
+	for _, pt := range s2d.Points() {
+		if math.IsNaN(pt.Y) {
+			continue
+		}
+		pts = append(pts, pt)
+	}
+	data := hbook.NewS2D(pts...)
+	for k, v := range s2d.Annotation() {
+		data.Annotation()[k] = v
+	}
+	return NewS2D(data, opts...)
+}
@@ -0,0 +1,37 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestP1D(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleNewP1D, t, "p1d.png")
+}
+
+func TestP1DAnnotation(t *testing.T) {
+	p1d := hbook.NewP1D(10, 0, 10)
+	p1d.Annotation()["name"] = "prof"
+	p1d.Annotation()["title"] = "my profile"
+	p1d.Fill(1.5, 2, 1)
+	p1d.Fill(4.5, 3, 1)
+
+	s := hplot.NewP1D(p1d)
+	if got, want := s.Data.Len(), 2; got != want {
+		t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+	}
+
+	ann := s.Data.(*hbook.S2D).Annotation()
+	for _, k := range []string{"name", "title"} {
+		if got, want := ann[k], p1d.Annotation()[k]; got != want {
+			t.Fatalf("invalid annotation %q: got=%v, want=%v", k, got, want)
+		}
+	}
+}
@@ -86,6 +86,39 @@ func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 	return WriterTo(p, w, h, format)
 }
 
+// PadRange pads the range of the Y axis of the plot, so that the data
+// does not touch the top edge of the frame.
+//
+// The maximum of the Y axis is raised by frac times the Y range.
+// For linear Y axes, a positive minimum of the Y axis is anchored at zero.
+// For log-scaled Y axes, the range is padded in log space.
+// A zero frac pads the range by 10%.
+//
+// PadRange should be called after all the plotters have been added.
+func PadRange(p *plot.Plot, frac float64) {
+	if frac == 0 {
+		frac = 0.1
+	}
+
+	ymin, ymax := p.Y.Min, p.Y.Max
+	if _, ok := p.Y.Scale.(plot.LogScale); ok {
+		if ymin > 0 && ymax > ymin {
+			p.Y.Max = ymax * math.Pow(ymax/ymin, frac)
+		}
+		return
+	}
+
+	if ymin > 0 {
+		ymin = 0
+	}
+	delta := ymax - ymin
+	if delta == 0 {
+		delta = math.Abs(ymax)
+	}
+	p.Y.Min = ymin
+	p.Y.Max = ymax + frac*delta
+}
+
 // Draw draws a plot to a draw.Canvas.
 //
 // Plotters are drawn in the order in which they were
@@ -5,10 +5,12 @@
 package hplot_test
 
 import (
+	"math"
 	"os"
 	"testing"
 
 	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/cmpimg"
 	"gonum.org/v1/plot/vg"
 )
@@ -42,3 +44,37 @@ func TestPlotWriterTo(t *testing.T) {
 		}
 	}, t, "plot_writerto.png")
 }
+
+func TestPadRange(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		ymin, ymax float64
+		frac       float64
+		log        bool
+		min, max   float64
+	}{
+		{name: "positive", ymin: 10, ymax: 100, frac: 0.05, min: 0, max: 105},
+		{name: "default", ymin: 10, ymax: 100, min: 0, max: 110},
+		{name: "negative", ymin: -10, ymax: 10, frac: 0.1, min: -10, max: 12},
+		{name: "flat", ymin: 5, ymax: 5, frac: 0.1, min: 0, max: 5.5},
+		{name: "log", ymin: 1, ymax: 100, frac: 0.5, log: true, min: 1, max: 1000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := hplot.New()
+			p.Y.Min = tc.ymin
+			p.Y.Max = tc.ymax
+			if tc.log {
+				p.Y.Scale = plot.LogScale{}
+			}
+
+			hplot.PadRange(p.Plot, tc.frac)
+
+			if got, want := p.Y.Min, tc.min; math.Abs(got-want) > 1e-9 {
+				t.Fatalf("invalid y-min: got=%v, want=%v", got, want)
+			}
+			if got, want := p.Y.Max, tc.max; math.Abs(got-want) > 1e-9 {
+				t.Fatalf("invalid y-max: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
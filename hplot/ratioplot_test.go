@@ -13,3 +13,7 @@ import (
 func TestRatioPlot(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleRatioPlot, t, "diff_plot.png")
 }
+
+func TestRatioPlotResiduals(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleRatioPlot_residuals, t, "residuals_plot.png")
+}
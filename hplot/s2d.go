@@ -135,12 +135,13 @@ func (pts *S2D) withBand() error {
 }
 
 // NewS2D creates a 2-dim scatter plot from a XYer.
+// The glyphs are drawn with the glyph style of the current default style,
+// unless the WithGlyphStyle option is provided.
 func NewS2D(data plotter.XYer, opts ...Options) *S2D {
 	s := &S2D{
 		Data:       data,
-		GlyphStyle: plotter.DefaultGlyphStyle,
+		GlyphStyle: DefaultStyle.GlyphStyle,
 	}
-	s.GlyphStyle.Shape = draw.CrossGlyph{}
 
 	cfg := newConfig(opts)
 
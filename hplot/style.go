@@ -5,8 +5,11 @@
 package hplot
 
 import (
+	"image/color"
+
 	"github.com/golang/freetype/truetype"
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 	"gonum.org/v1/plot/vg/fonts"
@@ -26,6 +29,111 @@ type Style struct {
 		Legend vg.Font // font used for the plot legend
 		Tick   vg.Font // font used for the plot's axes' ticks
 	}
+
+	TickLength vg.Length       // length of the major ticks of the plot's axes
+	LineStyle  draw.LineStyle  // default line style of plotters
+	GlyphStyle draw.GlyphStyle // default glyph style of plotters drawing points
+}
+
+// SetStyle sets the style used by plots and plotters.
+//
+// New plots are set up with the active style.
+// Plotters created with the constructors of this package use the active
+// style at draw time: the attributes of their line and text styles
+// which were not modified after their creation follow the active style.
+func SetStyle(s Style) {
+	DefaultStyle = s
+}
+
+// activeStyle returns a copy of the active style.
+// Plotters record the style active at their creation, to follow the
+// style active at draw time.
+func activeStyle() *Style {
+	sty := DefaultStyle
+	return &sty
+}
+
+// activeLineStyle returns the line style sty of a plotter created while
+// the style dflt was active, updated with the active style: the attributes
+// of sty still holding their value from dflt are taken from DefaultStyle.
+// sty is returned unchanged if dflt is nil.
+func activeLineStyle(sty draw.LineStyle, dflt *Style) draw.LineStyle {
+	if dflt == nil {
+		return sty
+	}
+	var (
+		old = dflt.LineStyle
+		cur = DefaultStyle.LineStyle
+	)
+	if sty.Width == old.Width {
+		sty.Width = cur.Width
+	}
+	if sameColor(sty.Color, old.Color) {
+		sty.Color = cur.Color
+	}
+	if sty.DashOffs == old.DashOffs && sameDashes(sty.Dashes, old.Dashes) {
+		sty.Dashes = cur.Dashes
+		sty.DashOffs = cur.DashOffs
+	}
+	return sty
+}
+
+// activeFont returns the font fnt of a plotter created while the font
+// dflt was the default one, replaced by the font cur of the active style
+// if it was not modified.
+func activeFont(fnt, dflt, cur vg.Font) vg.Font {
+	if fnt == dflt {
+		return cur
+	}
+	return fnt
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
+
+func sameDashes(a, b []vg.Length) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewDefaultStyle returns the default go-hep style.
+func NewDefaultStyle() Style {
+	return newPresetStyle(defaultFontSizes, vg.Points(8), plotter.DefaultLineStyle, vg.Points(2))
+}
+
+// NewPublicationStyle returns a style suited for publications,
+// with larger fonts and ticks, and thicker lines.
+func NewPublicationStyle() Style {
+	line := plotter.DefaultLineStyle
+	line.Width = vg.Points(2)
+	return newPresetStyle(fontSizes{title: 16, label: 16, legend: 14, tick: 14}, vg.Points(10), line, vg.Points(3))
+}
+
+func newPresetStyle(sizes fontSizes, tick vg.Length, line draw.LineStyle, glyph vg.Length) Style {
+	var sty Style
+	sty.Fonts.Name = defaultFontName
+	err := sty.makeFonts(sizes)
+	if err != nil {
+		// can not happen: the font has been registered at init.
+		panic(err)
+	}
+	sty.TickLength = tick
+	sty.LineStyle = line
+	sty.GlyphStyle = newGlyphStyle(glyph)
+	return sty
 }
 
 // Apply setups the plot p with the current style.
@@ -37,6 +145,10 @@ func (s *Style) Apply(p *Plot) {
 	p.Plot.Y.Tick.Label.Font = s.Fonts.Tick
 	p.Plot.Legend.TextStyle.Font = s.Fonts.Legend
 	p.Plot.Legend.YPosition = draw.PosCenter
+	if s.TickLength > 0 {
+		p.Plot.X.Tick.Length = s.TickLength
+		p.Plot.Y.Tick.Length = s.TickLength
+	}
 }
 
 func (s *Style) reset(name string) {
@@ -57,14 +169,40 @@ func NewStyle(name string, ft *truetype.Font) (Style, error) {
 func (sty *Style) init(name string, ft *truetype.Font) error {
 	sty.Fonts.Name = name
 	vg.AddFont(name, ft)
+	sty.TickLength = vg.Points(8)
+	sty.LineStyle = plotter.DefaultLineStyle
+	sty.GlyphStyle = newGlyphStyle(vg.Points(2))
+	return sty.makeFonts(defaultFontSizes)
+}
+
+// newGlyphStyle returns a black filled circle glyph of the given radius.
+func newGlyphStyle(radius vg.Length) draw.GlyphStyle {
+	return draw.GlyphStyle{
+		Color:  color.Black,
+		Radius: radius,
+		Shape:  draw.CircleGlyph{},
+	}
+}
+
+// fontSizes holds the sizes of the fonts of a style.
+type fontSizes struct {
+	title, label, legend, tick vg.Length
+}
+
+var defaultFontSizes = fontSizes{title: 12, label: 12, legend: 12, tick: 10}
+
+// defaultFontName is the name under which the default font is registered.
+const defaultFontName = "Helvetica"
+
+func (sty *Style) makeFonts(sizes fontSizes) error {
 	for _, t := range []struct {
 		ft   *vg.Font
 		size vg.Length
 	}{
-		{&sty.Fonts.Title, 12},
-		{&sty.Fonts.Label, 12},
-		{&sty.Fonts.Legend, 12},
-		{&sty.Fonts.Tick, 10},
+		{&sty.Fonts.Title, sizes.title},
+		{&sty.Fonts.Label, sizes.label},
+		{&sty.Fonts.Legend, sizes.legend},
+		{&sty.Fonts.Tick, sizes.tick},
 	} {
 		ft, err := vg.MakeFont(sty.Fonts.Name, t.size)
 		if err != nil {
@@ -87,7 +225,7 @@ func init() {
 		panic(err)
 	}
 
-	err = DefaultStyle.init("Helvetica", ft)
+	err = DefaultStyle.init(defaultFontName, ft)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,171 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func TestStylePresets(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		sty    Style
+		title  vg.Length
+		tick   vg.Length
+		length vg.Length
+		width  vg.Length
+		glyph  vg.Length
+	}{
+		{
+			name:   "default",
+			sty:    NewDefaultStyle(),
+			title:  12,
+			tick:   10,
+			length: vg.Points(8),
+			width:  vg.Points(1),
+			glyph:  vg.Points(2),
+		},
+		{
+			name:   "publication",
+			sty:    NewPublicationStyle(),
+			title:  16,
+			tick:   14,
+			length: vg.Points(10),
+			width:  vg.Points(2),
+			glyph:  vg.Points(3),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sty := tc.sty
+			if got, want := sty.Fonts.Name, defaultFontName; got != want {
+				t.Errorf("invalid font name: got=%q, want=%q", got, want)
+			}
+			if got, want := sty.Fonts.Title.Size, tc.title; got != want {
+				t.Errorf("invalid title font size: got=%v, want=%v", got, want)
+			}
+			if got, want := sty.Fonts.Tick.Size, tc.tick; got != want {
+				t.Errorf("invalid tick font size: got=%v, want=%v", got, want)
+			}
+			if got, want := sty.TickLength, tc.length; got != want {
+				t.Errorf("invalid tick length: got=%v, want=%v", got, want)
+			}
+			if got, want := sty.LineStyle.Width, tc.width; got != want {
+				t.Errorf("invalid line width: got=%v, want=%v", got, want)
+			}
+			if got, want := sty.GlyphStyle.Radius, tc.glyph; got != want {
+				t.Errorf("invalid glyph radius: got=%v, want=%v", got, want)
+			}
+			if _, ok := sty.GlyphStyle.Shape.(draw.CircleGlyph); !ok {
+				t.Errorf("invalid glyph shape: got=%T, want=%T", sty.GlyphStyle.Shape, draw.CircleGlyph{})
+			}
+		})
+	}
+}
+
+func TestSetStyle(t *testing.T) {
+	old := DefaultStyle
+	defer SetStyle(old)
+
+	if got, want := old.TickLength, NewDefaultStyle().TickLength; got != want {
+		t.Fatalf("invalid default tick length: got=%v, want=%v", got, want)
+	}
+
+	pub := NewPublicationStyle()
+	SetStyle(pub)
+
+	p := New()
+	if got, want := p.Title.TextStyle.Font.Size, pub.Fonts.Title.Size; got != want {
+		t.Errorf("invalid title font size: got=%v, want=%v", got, want)
+	}
+	for _, tick := range []vg.Length{p.X.Tick.Length, p.Y.Tick.Length} {
+		if got, want := tick, pub.TickLength; got != want {
+			t.Errorf("invalid tick length: got=%v, want=%v", got, want)
+		}
+	}
+
+	h := NewH1D(hbook.NewH1D(10, 0, 1), WithDrawMode(Points))
+	if got, want := h.LineStyle.Width, pub.LineStyle.Width; got != want {
+		t.Errorf("invalid h1d line width: got=%v, want=%v", got, want)
+	}
+	if got, want := h.glyphStyle().Radius, pub.GlyphStyle.Radius; got != want {
+		t.Errorf("invalid h1d glyph radius: got=%v, want=%v", got, want)
+	}
+
+	f := NewFunction(func(x float64) float64 { return x })
+	if got, want := f.LineStyle.Width, pub.LineStyle.Width; got != want {
+		t.Errorf("invalid function line width: got=%v, want=%v", got, want)
+	}
+
+	for _, line := range []vg.Length{
+		HLine(0, nil, nil).Line.Width,
+		VLine(0, nil, nil).Line.Width,
+	} {
+		if got, want := line, pub.LineStyle.Width; got != want {
+			t.Errorf("invalid line width: got=%v, want=%v", got, want)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		sty  draw.GlyphStyle
+	}{
+		{"s2d", NewS2D(hbook.NewS2D()).GlyphStyle},
+		{"p1d", NewP1D(hbook.NewP1D(10, 0, 1)).GlyphStyle},
+	} {
+		if got, want := tc.sty, pub.GlyphStyle; got != want {
+			t.Errorf("%s: invalid glyph style: got=%+v, want=%+v", tc.name, got, want)
+		}
+	}
+}
+
+func TestSetStyleAfterCreation(t *testing.T) {
+	old := DefaultStyle
+	defer SetStyle(old)
+
+	SetStyle(NewDefaultStyle())
+
+	var (
+		h     = NewH1D(hbook.NewH1D(10, 0, 1))
+		f     = NewFunction(func(x float64) float64 { return x })
+		hline = HLine(0, nil, nil)
+		vline = VLine(0, nil, nil)
+		leg   = NewLegend()
+		usr   = NewH1D(hbook.NewH1D(10, 0, 1))
+	)
+	usr.LineStyle.Width = vg.Points(3)
+	leg.BoxStyle.Color = color.NRGBA{R: 255, A: 255}
+
+	pub := NewPublicationStyle()
+	SetStyle(pub)
+
+	for _, tc := range []struct {
+		name string
+		sty  draw.LineStyle
+		want vg.Length
+	}{
+		{"h1d", h.lineStyle(), pub.LineStyle.Width},
+		{"func", activeLineStyle(f.LineStyle, f.style), pub.LineStyle.Width},
+		{"hline", activeLineStyle(hline.Line, hline.style), pub.LineStyle.Width},
+		{"vline", activeLineStyle(vline.Line, vline.style), pub.LineStyle.Width},
+		{"legend", leg.boxStyle(), pub.LineStyle.Width},
+		{"user", usr.lineStyle(), vg.Points(3)},
+	} {
+		if got, want := tc.sty.Width, tc.want; got != want {
+			t.Errorf("%s: invalid line width: got=%v, want=%v", tc.name, got, want)
+		}
+	}
+
+	if got, want := leg.textStyle().Font.Size, pub.Fonts.Legend.Size; got != want {
+		t.Errorf("invalid legend font size: got=%v, want=%v", got, want)
+	}
+	if got, want := leg.boxStyle().Color, leg.BoxStyle.Color; got != want {
+		t.Errorf("invalid legend box color: got=%v, want=%v", got, want)
+	}
+}
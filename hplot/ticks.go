@@ -94,3 +94,61 @@ func (tck Ticks) Ticks(min, max float64) []plot.Tick {
 	}
 	return ticks
 }
+
+// LogTicks implements plot.Ticker for log-scaled axes.
+// Labeled major ticks are placed at powers of ten and unlabeled minor
+// ticks are placed at 2, 3, ..., 9 times these powers of ten.
+// If the range does not contain any power of ten, the minor ticks are labeled.
+//
+// LogTicks panics if min or max is not strictly positive.
+type LogTicks struct {
+	// Format is an optional major-tick formatter.
+	// If empty, a format will be automatically chosen.
+	Format string
+}
+
+// Ticks returns Ticks in a specified range
+func (tck LogTicks) Ticks(min, max float64) []plot.Tick {
+	if min <= 0 || max <= 0 {
+		panic("hplot: values must be strictly positive for a log scale")
+	}
+
+	lo := int(math.Floor(math.Log10(min)))
+	if math.Pow10(lo+1) <= min {
+		lo++ // correct for rounding errors of math.Log10.
+	}
+	hi := int(math.Ceil(math.Log10(max)))
+	if math.Pow10(hi-1) >= max {
+		hi--
+	}
+
+	var (
+		ticks = make([]plot.Tick, 0, 9*(hi-lo)+1)
+		major = false
+	)
+	for e := lo; e <= hi; e++ {
+		v := math.Pow10(e)
+		major = major || (min <= v && v <= max)
+		ticks = append(ticks, plot.Tick{Value: v, Label: tck.label(v)})
+		if e == hi {
+			break
+		}
+		for i := 2; i < 10; i++ {
+			ticks = append(ticks, plot.Tick{Value: float64(i) * v})
+		}
+	}
+
+	if !major {
+		for i, t := range ticks {
+			ticks[i].Label = tck.label(t.Value)
+		}
+	}
+	return ticks
+}
+
+func (tck LogTicks) label(v float64) string {
+	if tck.Format != "" {
+		return fmt.Sprintf(tck.Format, v)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
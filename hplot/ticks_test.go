@@ -5,11 +5,90 @@
 package hplot_test
 
 import (
+	"reflect"
 	"testing"
 
+	"go-hep.org/x/hep/hplot"
 	"gonum.org/v1/plot/cmpimg"
 )
 
 func TestTicks(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleTicks, t, "ticks.png")
 }
+
+func TestLogTicks(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		tck      hplot.LogTicks
+		min, max float64
+		n        int
+		labels   map[float64]string
+	}{
+		{
+			name: "decades",
+			min:  1,
+			max:  1000,
+			n:    28,
+			labels: map[float64]string{
+				1: "1", 10: "10", 100: "100", 1000: "1000",
+			},
+		},
+		{
+			name: "partial-decades",
+			min:  0.05,
+			max:  20,
+			n:    37,
+			labels: map[float64]string{
+				0.01: "0.01", 0.1: "0.1", 1: "1", 10: "10", 100: "100",
+			},
+		},
+		{
+			name: "format",
+			tck:  hplot.LogTicks{Format: "%.0e"},
+			min:  1e-3,
+			max:  1e-1,
+			n:    19,
+			labels: map[float64]string{
+				1e-3: "1e-03", 1e-2: "1e-02", 1e-1: "1e-01",
+			},
+		},
+		{
+			name: "no-decade",
+			min:  2,
+			max:  8,
+			n:    10,
+			labels: map[float64]string{
+				1: "1", 2: "2", 3: "3", 4: "4", 5: "5",
+				6: "6", 7: "7", 8: "8", 9: "9", 10: "10",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ticks := tc.tck.Ticks(tc.min, tc.max)
+			if got, want := len(ticks), tc.n; got != want {
+				t.Fatalf("invalid number of ticks: got=%d, want=%d", got, want)
+			}
+			labels := make(map[float64]string)
+			for i, tick := range ticks {
+				if i > 0 && tick.Value <= ticks[i-1].Value {
+					t.Fatalf("ticks not sorted: %v", ticks)
+				}
+				if !tick.IsMinor() {
+					labels[tick.Value] = tick.Label
+				}
+			}
+			if !reflect.DeepEqual(labels, tc.labels) {
+				t.Fatalf("invalid labels:\ngot= %v\nwant=%v", labels, tc.labels)
+			}
+		})
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic")
+			}
+		}()
+		hplot.LogTicks{}.Ticks(0, 10)
+	}()
+}
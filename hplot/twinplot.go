@@ -0,0 +1,220 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// TwinPlot overlays two sets of plotters sharing the same X axis, each
+// with its own Y axis.
+// The Y axis of the primary plot is drawn on the left side of the plot,
+// the secondary Y axis is drawn on the right side.
+type TwinPlot struct {
+	// Plot is the primary plot.
+	// It holds the shared X axis and the left Y axis.
+	Plot *Plot
+
+	// Y2 is the secondary Y axis, drawn on the right side of the plot.
+	Y2 plot.Axis
+
+	plotters []plot.Plotter // plotters drawn against the secondary Y axis
+}
+
+// NewTwinPlot returns a twin plot drawing the plotters ps against a
+// secondary Y axis, on top of the primary plot p.
+//
+// The secondary Y axis is styled like the Y axis of p.
+func NewTwinPlot(p *Plot, ps ...plot.Plotter) *TwinPlot {
+	tp := &TwinPlot{
+		Plot: p,
+		Y2:   p.Y,
+	}
+	tp.Y2.Min = math.Inf(+1)
+	tp.Y2.Max = math.Inf(-1)
+	tp.Y2.Label.Text = ""
+	tp.Y2.Scale = plot.LinearScale{}
+	tp.Y2.Tick.Marker = plot.DefaultTicks{}
+
+	tp.AddY2(ps...)
+	return tp
+}
+
+// AddY2 adds plotters drawn against the secondary Y axis.
+//
+// If the plotters implement DataRanger, the range of the shared X axis
+// and of the secondary Y axis are extended to fit the data.
+//
+// Plotters of the secondary Y axis are drawn after the ones of the
+// primary plot, in the order in which they were added.
+func (tp *TwinPlot) AddY2(ps ...plot.Plotter) {
+	for _, d := range ps {
+		if x, ok := d.(plot.DataRanger); ok {
+			xmin, xmax, ymin, ymax := x.DataRange()
+			tp.Plot.X.Min = math.Min(tp.Plot.X.Min, xmin)
+			tp.Plot.X.Max = math.Max(tp.Plot.X.Max, xmax)
+			tp.Y2.Min = math.Min(tp.Y2.Min, ymin)
+			tp.Y2.Max = math.Max(tp.Y2.Max, ymax)
+		}
+	}
+	tp.plotters = append(tp.plotters, ps...)
+}
+
+// Draw draws the twin plot to a draw.Canvas.
+//
+// The primary plot is drawn first, leaving room on the right for the
+// secondary Y axis.
+// The plotters of the secondary Y axis are then drawn inside the data
+// area of the primary plot, using the shared X axis and the secondary
+// Y axis.
+func (tp *TwinPlot) Draw(dc draw.Canvas) {
+	y2 := tp.Y2
+	sanitizeAxisRange(&y2)
+
+	c := draw.Crop(dc, 0, -rightAxisWidth(y2), 0, 0)
+	tp.Plot.Draw(c)
+
+	aux := *tp.Plot.Plot
+	aux.Y = y2
+
+	data := aux.DataCanvas(c)
+	for _, p := range tp.plotters {
+		p.Plot(data, &aux)
+	}
+
+	drawRightAxis(data, c.Max.X, y2)
+}
+
+// Save saves the twin plot to an image file.
+// The file format is determined by the extension.
+//
+// See Plot.Save for the supported extensions.
+func (tp *TwinPlot) Save(w, h vg.Length, file string) error {
+	return Save(tp, w, h, file)
+}
+
+// sanitizeAxisRange ensures the range of the axis makes sense,
+// as plot.Plot does for its own axes.
+func sanitizeAxisRange(a *plot.Axis) {
+	if math.IsInf(a.Min, 0) {
+		a.Min = 0
+	}
+	if math.IsInf(a.Max, 0) {
+		a.Max = 0
+	}
+	if a.Min > a.Max {
+		a.Min, a.Max = a.Max, a.Min
+	}
+	if a.Min == a.Max {
+		a.Min--
+		a.Max++
+	}
+}
+
+// rightAxisWidth returns the width of a vertical axis drawn on the
+// right side of a plot.
+func rightAxisWidth(a plot.Axis) vg.Length {
+	var w vg.Length
+	if a.Label.Text != "" {
+		w -= a.Label.Font.Extents().Descent
+		w += a.Label.Height(a.Label.Text)
+		w += a.Label.Padding
+	}
+
+	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	if len(marks) > 0 {
+		if lwidth := tickLabelWidth(a.Tick.Label, marks); lwidth > 0 {
+			w += lwidth
+			w += a.Label.Width(" ")
+		}
+		if a.Tick.Width > 0 && a.Tick.Length > 0 {
+			w += a.Tick.Length
+		}
+	}
+	w += a.Width / 2
+	w += a.Padding
+
+	return w
+}
+
+// drawRightAxis draws the vertical axis a, starting at x0 and growing
+// to the right.
+// The positions of the ticks are taken from the data canvas.
+func drawRightAxis(data draw.Canvas, x0 vg.Length, a plot.Axis) {
+	x := x0 + a.Padding + a.Width/2
+	data.StrokeLine2(a.LineStyle, x, data.Min.Y, x, data.Max.Y)
+
+	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	if a.Tick.Width > 0 && a.Tick.Length > 0 && len(marks) > 0 {
+		for _, t := range marks {
+			y := data.Y(a.Norm(t.Value))
+			if !data.ContainsY(y) {
+				continue
+			}
+			end := a.Tick.Length
+			if t.IsMinor() {
+				end /= 2
+			}
+			data.StrokeLine2(a.Tick.LineStyle, x, y, x+end, y)
+		}
+		x += a.Tick.Length
+	}
+
+	lwidth := tickLabelWidth(a.Tick.Label, marks)
+	if len(marks) > 0 && lwidth > 0 {
+		x += a.Tick.Label.Width(" ")
+		sty := a.Tick.Label
+		sty.XAlign = draw.XLeft
+		for _, t := range marks {
+			y := data.Y(a.Norm(t.Value))
+			if !data.ContainsY(y) || t.IsMinor() {
+				continue
+			}
+			data.FillText(sty, vg.Point{X: x, Y: y}, t.Label)
+		}
+		x += lwidth
+	}
+
+	if a.Label.Text != "" {
+		x += a.Label.Padding
+		x -= a.Label.Font.Extents().Descent
+		x += a.Label.Height(a.Label.Text)
+
+		sty := a.Label.TextStyle
+		sty.Rotation += math.Pi / 2
+		var y vg.Length
+		switch a.Label.Position {
+		case draw.PosCenter:
+			y = data.Center().Y
+		case draw.PosTop:
+			y = data.Max.Y
+			y -= a.Label.Font.Width(a.Label.Text) / 2
+		}
+		data.FillText(sty, vg.Point{X: x, Y: y}, a.Label.Text)
+	}
+}
+
+// tickLabelWidth returns the width of the widest tick mark label.
+func tickLabelWidth(sty draw.TextStyle, ticks []plot.Tick) vg.Length {
+	var max vg.Length
+	for _, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		r := sty.Rectangle(t.Label)
+		if w := r.Max.X - r.Min.X; w > max {
+			max = w
+		}
+	}
+	return max
+}
+
+var (
+	_ Drawer = (*TwinPlot)(nil)
+)
@@ -6,9 +6,12 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/auth"
 )
@@ -27,8 +30,15 @@ type Client struct {
 	initialSessionID string
 	mu               sync.RWMutex
 	sessions         map[string]*cliSession
+	info             ServerInfo // info is the description of the server of the initial session.
 
 	maxRedirections int
+	retry           RetryPolicy
+	timeout         time.Duration
+	stats           *statCache // stats caches the results of FileSystem.Stat, if enabled.
+
+	fmu   sync.Mutex
+	files map[*file]struct{} // files opened by the client and not closed yet.
 }
 
 // Option configures an XRootD client.
@@ -43,6 +53,21 @@ func WithAuth(a auth.Auther) Option {
 	}
 }
 
+// WithTimeout sets the maximum duration of each request sent by the XRootD client,
+// including the time spent following redirections and retrying.
+// Requests that do not complete in time fail with an error wrapping ErrTimeout.
+// If the context of a request already has an earlier deadline, that deadline applies.
+// A zero duration means no timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(client *Client) error {
+		client.timeout = d
+		return nil
+	}
+}
+
+// ErrTimeout is returned when a request did not complete within the timeout of the client.
+var ErrTimeout = errors.New("xrootd: request timed out")
+
 func (client *Client) addAuth(auth auth.Auther) error {
 	client.auths[auth.Provider()] = auth
 	return nil
@@ -111,6 +136,59 @@ func (client *Client) Close() error {
 	return nil
 }
 
+// OpenFiles returns the files opened through the file system of the client
+// that have not been successfully closed yet, in no particular order.
+func (client *Client) OpenFiles() []xrdfs.File {
+	client.fmu.Lock()
+	defer client.fmu.Unlock()
+	files := make([]xrdfs.File, 0, len(client.files))
+	for f := range client.files {
+		files = append(files, f)
+	}
+	return files
+}
+
+// CloseAll closes all the files returned by OpenFiles.
+// CloseAll tries to close every file, even if closing one of them failed.
+// Files that could not be closed are still returned by OpenFiles.
+func (client *Client) CloseAll(ctx context.Context) error {
+	var errs []error
+	for _, f := range client.OpenFiles() {
+		err := f.Close(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("xrootd: could not close %d files: %v", len(errs), errs)
+	}
+	return nil
+}
+
+func (client *Client) addFile(f *file) {
+	client.fmu.Lock()
+	defer client.fmu.Unlock()
+	if client.files == nil {
+		client.files = make(map[*file]struct{})
+	}
+	client.files[f] = struct{}{}
+}
+
+func (client *Client) removeFile(f *file) {
+	client.fmu.Lock()
+	defer client.fmu.Unlock()
+	delete(client.files, f)
+}
+
+// ServerInfo returns the description of the server the client is connected to,
+// as advertised by the server during the handshake and the protocol request.
+// It may be used to select code paths depending on the server version or role.
+func (client *Client) ServerInfo() ServerInfo {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+	return client.info
+}
+
 // Send sends the request to the server and stores the response inside the resp.
 // If the resp is nil, then no response is stored.
 // Send returns a session id which identifies the server that provided response.
@@ -118,22 +196,71 @@ func (client *Client) Send(ctx context.Context, resp xrdproto.Response, req xrdp
 	return client.sendSession(ctx, client.initialSessionID, resp, req)
 }
 
+// AsyncResponse holds the outcome of a request sent with SendAsync.
+type AsyncResponse struct {
+	// SessionID identifies the server that provided the response.
+	SessionID string
+	// Err is the error returned by the request, if any.
+	Err error
+}
+
+// SendAsync sends the request to the server without waiting for its response.
+// The response is stored inside the resp, which must not be accessed until
+// the outcome of the request has been received from the returned channel.
+// If the resp is nil, then no response is stored.
+//
+// Multiple requests may be in flight on the same connection: replies
+// from the server are matched back to their request via the stream ID.
+func (client *Client) SendAsync(ctx context.Context, resp xrdproto.Response, req xrdproto.Request) (<-chan AsyncResponse, error) {
+	// the session is not bound to the context of this request,
+	// as it may be re-used by subsequent requests.
+	_, err := client.getSession(context.Background(), client.initialSessionID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan AsyncResponse, 1)
+	go func() {
+		id, err := client.sendSession(ctx, client.initialSessionID, resp, req)
+		ch <- AsyncResponse{SessionID: id, Err: err}
+	}()
+	return ch, nil
+}
+
 func (client *Client) sendSession(ctx context.Context, sessionID string, resp xrdproto.Response, req xrdproto.Request) (string, error) {
-	client.mu.RLock()
-	session, ok := client.sessions[sessionID]
-	client.mu.RUnlock()
-	if !ok {
-		return "", fmt.Errorf("xrootd: session with id = %q was not found", sessionID)
+	if client.timeout <= 0 {
+		return client.send(ctx, sessionID, resp, req)
 	}
 
-	redirection, err := session.Send(ctx, resp, req)
+	tctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	sessionID, err := client.send(tctx, sessionID, resp, req)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		err = fmt.Errorf("%w (after %v)", ErrTimeout, client.timeout)
+	}
+	return sessionID, err
+}
+
+func (client *Client) send(ctx context.Context, sessionID string, resp xrdproto.Response, req xrdproto.Request) (string, error) {
+	// the session is re-established if it was dropped after a connection failure.
+	// it is not bound to the context of this request, as it may be re-used by
+	// subsequent requests.
+	session, err := client.getSession(context.Background(), sessionID, "")
+	if err != nil {
+		return sessionID, err
+	}
+
+	redirection, err := client.sendRetry(ctx, sessionID, session, resp, req)
 	if err != nil {
 		return sessionID, err
 	}
 
 	for cnt := client.maxRedirections; redirection != nil && cnt > 0; cnt-- {
 		sessionID = redirection.Addr
-		session, err = client.getSession(ctx, sessionID, redirection.Token)
+		// the session is not bound to the context of this request,
+		// as it may be re-used by subsequent requests.
+		session, err = client.getSession(context.Background(), sessionID, redirection.Token)
 		if err != nil {
 			return sessionID, err
 		}
@@ -141,7 +268,7 @@ func (client *Client) sendSession(ctx context.Context, sessionID string, resp xr
 			fp.SetOpaque(redirection.Opaque)
 		}
 		// TODO: we should check if the request contains file handle and re-issue open request in that case.
-		redirection, err = session.Send(ctx, resp, req)
+		redirection, err = client.sendRetry(ctx, sessionID, session, resp, req)
 		if err != nil {
 			return sessionID, err
 		}
@@ -163,6 +290,10 @@ func (client *Client) getSession(ctx context.Context, address, token string) (*c
 	}
 	client.mu.Lock()
 	defer client.mu.Unlock()
+	if v, ok := client.sessions[address]; ok {
+		// session created by a concurrent request.
+		return v, nil
+	}
 	session, err := newSession(ctx, address, client.username, token, client)
 	if err != nil {
 		return nil, err
@@ -172,6 +303,9 @@ func (client *Client) getSession(ctx context.Context, address, token string) (*c
 	if len(client.initialSessionID) == 0 {
 		client.initialSessionID = address
 	}
+	if address == client.initialSessionID {
+		client.info = session.info
+	}
 	// TODO: check if initial sessionID should be changed.
 	// See http://xrootd.org/doc/dev45/XRdv310.pdf, p. 11 for details.
 
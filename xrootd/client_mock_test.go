@@ -0,0 +1,84 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/ping"
+)
+
+func TestClient_Timeout_Mock(t *testing.T) {
+	serverFunc := func(cancel func(), conn net.Conn) {
+		var streams []xrdproto.StreamID
+		for i := 0; i < 3; i++ {
+			data, err := xrdproto.ReadRequest(conn)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not read request: %v", err)
+			}
+
+			var gotRequest ping.Request
+			gotHeader, err := unmarshalRequest(data, &gotRequest)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not unmarshal request: %v", err)
+			}
+			streams = append(streams, gotHeader.StreamID)
+
+			if i < 2 {
+				// do not reply to the first requests before the client gave up on them.
+				continue
+			}
+
+			for _, id := range streams {
+				err = xrdproto.WriteResponse(conn, id, xrdproto.Ok, nil)
+				if err != nil {
+					cancel()
+					t.Fatalf("could not write response: %v", err)
+				}
+			}
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		client.timeout = 50 * time.Millisecond
+		_, err := client.Send(context.Background(), nil, &ping.Request{})
+		if !errors.Is(err, ErrTimeout) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, ErrTimeout)
+		}
+
+		// the earlier deadline wins.
+		client.timeout = time.Hour
+		ctx, cancelCtx := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancelCtx()
+		_, err = client.Send(ctx, nil, &ping.Request{})
+		if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrTimeout) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, context.DeadlineExceeded)
+		}
+
+		// abandoned requests are released.
+		sess := client.sessions[client.initialSessionID]
+		sess.mu.RLock()
+		n := len(sess.requests)
+		sess.mu.RUnlock()
+		if n != 0 {
+			t.Fatalf("abandoned requests were not released: got=%d pending requests, want=0", n)
+		}
+
+		// late replies to abandoned requests do not prevent subsequent requests to complete.
+		_, err = client.Send(context.Background(), nil, &ping.Request{})
+		if err != nil {
+			t.Fatalf("invalid ping call: %v", err)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
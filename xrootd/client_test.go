@@ -6,7 +6,15 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
 )
 
 func TestNewClient(t *testing.T) {
@@ -34,6 +42,116 @@ func testNewClient(t *testing.T, addr string) {
 	}
 }
 
+func TestClientServerInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xrootd-info-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	srv := NewServer(NewFSHandler(dir), func(err error) {})
+	go srv.Serve(l)
+	defer srv.Shutdown(context.Background())
+
+	cli, err := NewClient(context.Background(), l.Addr().String(), "gopher")
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	want := ServerInfo{
+		ProtocolVersion: 0x310,
+		Type:            xrdproto.DataServer,
+		Flags:           protocol.IsServer,
+	}
+	info := cli.ServerInfo()
+	if info != want {
+		t.Fatalf("invalid server info:\ngot= %+v\nwant=%+v", info, want)
+	}
+	if !info.IsServer() || info.IsManager() || info.IsProxy() {
+		t.Fatalf("invalid server roles: %+v", info)
+	}
+}
+
+func TestClientOpenFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xrootd-open-files-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	names := []string{"file1.txt", "file2.txt", "file3.txt"}
+	for _, name := range names {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644)
+		if err != nil {
+			t.Fatalf("could not create test file: %v", err)
+		}
+	}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	srv := NewServer(NewFSHandler(dir), func(err error) {})
+	go srv.Serve(l)
+	defer srv.Shutdown(context.Background())
+
+	ctx := context.Background()
+	cli, err := NewClient(ctx, l.Addr().String(), "gopher")
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	if got := len(cli.OpenFiles()); got != 0 {
+		t.Fatalf("invalid number of open files: got=%d, want=0", got)
+	}
+
+	fs := cli.FS()
+	var files []xrdfs.File
+	for _, name := range names {
+		f, err := fs.Open(ctx, name, xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+		if err != nil {
+			t.Fatalf("could not open %q: %v", name, err)
+		}
+		files = append(files, f)
+	}
+
+	if got, want := len(cli.OpenFiles()), len(names); got != want {
+		t.Fatalf("invalid number of open files: got=%d, want=%d", got, want)
+	}
+
+	err = files[0].Close(ctx)
+	if err != nil {
+		t.Fatalf("could not close file: %v", err)
+	}
+	if got, want := len(cli.OpenFiles()), len(names)-1; got != want {
+		t.Fatalf("invalid number of open files after close: got=%d, want=%d", got, want)
+	}
+
+	err = cli.CloseAll(ctx)
+	if err != nil {
+		t.Fatalf("could not close all files: %v", err)
+	}
+	if got := len(cli.OpenFiles()); got != 0 {
+		t.Fatalf("invalid number of open files after close-all: got=%d, want=0", got)
+	}
+
+	// the handles of the files were released.
+	for _, f := range files[1:] {
+		err := f.Close(ctx)
+		if err == nil {
+			t.Fatalf("expected an error closing an already closed file")
+		}
+	}
+}
+
 func BenchmarkNewClient(b *testing.B) {
 	for _, addr := range testClientAddrs {
 		b.Run(addr, func(b *testing.B) {
@@ -0,0 +1,264 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+)
+
+// CopyOption configures a Copy operation.
+type CopyOption func(*copyConfig)
+
+type copyConfig struct {
+	progress func(n, size int64)
+	resume   bool
+}
+
+// WithCopyProgress registers a function called after each chunk has been
+// copied, with the number of bytes of the destination file written so far
+// and the total size of the source file.
+func WithCopyProgress(f func(n, size int64)) CopyOption {
+	return func(cfg *copyConfig) {
+		cfg.progress = f
+	}
+}
+
+// WithCopyResume resumes a previous, partial, transfer: if the destination
+// file already exists and is smaller than the source file, only the missing
+// bytes are copied.
+func WithCopyResume() CopyOption {
+	return func(cfg *copyConfig) {
+		cfg.resume = true
+	}
+}
+
+// Copy copies the src file to dst and returns the number of bytes transferred.
+//
+// Each of dst and src may be either a local path or a remote one, of the form
+// root://server.example.com//path/to/file.
+// Remote paths must refer to the server this client is connected to.
+// At least one of dst and src must be remote, and dst and src must not be
+// the same remote file.
+//
+// Data is transferred in chunks of at most the maximum size of a write request.
+// The context is checked for cancellation between chunks.
+func (cli *Client) Copy(ctx context.Context, dst, src string, opts ...CopyOption) (int64, error) {
+	var cfg copyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	spath, sremote, err := cli.parseCopyPath(src)
+	if err != nil {
+		return 0, err
+	}
+	dpath, dremote, err := cli.parseCopyPath(dst)
+	if err != nil {
+		return 0, err
+	}
+	if !sremote && !dremote {
+		return 0, fmt.Errorf("xrootd: could not copy %q to %q: no remote file", src, dst)
+	}
+	if sremote && dremote && path.Clean(spath) == path.Clean(dpath) {
+		// opening the destination would truncate the source.
+		return 0, fmt.Errorf("xrootd: could not copy %q to %q: same file", src, dst)
+	}
+
+	r, size, err := cli.openCopySrc(ctx, spath, sremote)
+	if err != nil {
+		return 0, fmt.Errorf("xrootd: could not open copy source %q: %w", src, err)
+	}
+	defer r.close(ctx)
+
+	var off int64
+	if cfg.resume {
+		off, err = cli.copyDstSize(ctx, dpath, dremote)
+		if err != nil {
+			return 0, fmt.Errorf("xrootd: could not stat copy destination %q: %w", dst, err)
+		}
+		if off > size {
+			return 0, fmt.Errorf("xrootd: could not resume copy: destination %q larger than source %q (%d > %d)", dst, src, off, size)
+		}
+	}
+
+	w, err := cli.openCopyDst(ctx, dpath, dremote, off > 0)
+	if err != nil {
+		return 0, fmt.Errorf("xrootd: could not open copy destination %q: %w", dst, err)
+	}
+	defer w.close(ctx)
+
+	var (
+		n   int64
+		buf = make([]byte, maxWriteSize)
+	)
+	for off < size {
+		err = ctx.Err()
+		if err != nil {
+			return n, err
+		}
+
+		nn, err := r.f.ReadAt(buf, off)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return n, fmt.Errorf("xrootd: could not read from %q: %w", src, err)
+		}
+		if nn == 0 {
+			return n, fmt.Errorf("xrootd: could not read from %q: %w", src, io.ErrUnexpectedEOF)
+		}
+
+		_, err = w.f.WriteAt(buf[:nn], off)
+		if err != nil {
+			return n, fmt.Errorf("xrootd: could not write to %q: %w", dst, err)
+		}
+		n += int64(nn)
+		off += int64(nn)
+
+		if cfg.progress != nil {
+			cfg.progress(off, size)
+		}
+	}
+
+	err = w.close(ctx)
+	if err != nil {
+		return n, fmt.Errorf("xrootd: could not close copy destination %q: %w", dst, err)
+	}
+
+	return n, nil
+}
+
+// copyFile is a local or remote file involved in a Copy operation.
+type copyFile struct {
+	f interface {
+		io.ReaderAt
+		io.WriterAt
+	}
+	closed bool
+	cfunc  func(ctx context.Context) error
+}
+
+func (f *copyFile) close(ctx context.Context) error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	return f.cfunc(ctx)
+}
+
+func newLocalCopyFile(f *os.File) *copyFile {
+	return &copyFile{
+		f:     f,
+		cfunc: func(context.Context) error { return f.Close() },
+	}
+}
+
+func newRemoteCopyFile(f xrdfs.File) *copyFile {
+	return &copyFile{f: f, cfunc: f.Close}
+}
+
+func (cli *Client) openCopySrc(ctx context.Context, path string, remote bool) (*copyFile, int64, error) {
+	if !remote {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		fi, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+		return newLocalCopyFile(f), fi.Size(), nil
+	}
+
+	f, err := cli.FS().Open(ctx, path, xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+	if err != nil {
+		return nil, 0, err
+	}
+	fi, err := f.Stat(ctx)
+	if err != nil {
+		f.Close(ctx)
+		return nil, 0, err
+	}
+	return newRemoteCopyFile(f), fi.EntrySize, nil
+}
+
+func (cli *Client) openCopyDst(ctx context.Context, path string, remote, resume bool) (*copyFile, error) {
+	if !remote {
+		flags := os.O_WRONLY | os.O_CREATE
+		if !resume {
+			flags |= os.O_TRUNC
+		}
+		f, err := os.OpenFile(path, flags, 0644)
+		if err != nil {
+			return nil, err
+		}
+		return newLocalCopyFile(f), nil
+	}
+
+	opts := xrdfs.OpenOptionsOpenUpdate
+	if !resume {
+		opts |= xrdfs.OpenOptionsDelete
+	}
+	f, err := cli.FS().Open(ctx, path, xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newRemoteCopyFile(f), nil
+}
+
+// copyDstSize returns the size of the destination file of a Copy operation,
+// or zero if that file does not exist.
+func (cli *Client) copyDstSize(ctx context.Context, path string, remote bool) (int64, error) {
+	if !remote {
+		fi, err := os.Stat(path)
+		switch {
+		case os.IsNotExist(err):
+			return 0, nil
+		case err != nil:
+			return 0, err
+		}
+		return fi.Size(), nil
+	}
+
+	fi, err := cli.FS().Stat(ctx, path)
+	if err != nil {
+		var serr xrdproto.ServerError
+		if errors.As(err, &serr) && serr.Code == xrdproto.NotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return fi.EntrySize, nil
+}
+
+// parseCopyPath returns the path to a file and whether it is a remote one.
+func (cli *Client) parseCopyPath(name string) (string, bool, error) {
+	if !strings.HasPrefix(name, "root://") && !strings.HasPrefix(name, "xroot://") {
+		return name, false, nil
+	}
+
+	urn, err := url.Parse(name)
+	if err != nil {
+		return "", false, fmt.Errorf("xrootd: could not parse %q as a URL: %w", name, err)
+	}
+
+	if parseAddr(urn.Host) != cli.initialSessionID {
+		return "", false, fmt.Errorf("xrootd: could not access %q: client is connected to %q", name, cli.initialSessionID)
+	}
+
+	path := urn.Path
+	if strings.HasPrefix(path, "//") {
+		path = path[1:]
+	}
+	return path, true, nil
+}
@@ -0,0 +1,171 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd_test // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+
+	"go-hep.org/x/hep/xrootd"
+)
+
+func TestClientCopy(t *testing.T) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer srv.Shutdown(context.Background())
+
+	tmp, err := ioutil.TempDir("", "xrootd-copy-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	// larger than a single chunk.
+	want := make([]byte, 5*1024*1024+3)
+	rand.New(rand.NewSource(1234)).Read(want)
+
+	err = ioutil.WriteFile(path.Join(tmp, "src.dat"), want, 0644)
+	if err != nil {
+		t.Fatalf("could not create source file: %v", err)
+	}
+
+	remote := func(name string) string {
+		return "root://" + addr + "//" + name
+	}
+
+	for _, tc := range []struct {
+		name    string
+		dst     string
+		src     string
+		out     string // path of dst on the local filesystem
+		partial int    // size of the pre-existing partial dst, if any
+	}{
+		{
+			name: "local-to-remote",
+			dst:  remote("remote.dat"),
+			src:  path.Join(tmp, "src.dat"),
+			out:  path.Join(baseDir, "remote.dat"),
+		},
+		{
+			name: "remote-to-local",
+			dst:  path.Join(tmp, "local.dat"),
+			src:  remote("remote.dat"),
+			out:  path.Join(tmp, "local.dat"),
+		},
+		{
+			name: "remote-to-remote",
+			dst:  remote("remote-copy.dat"),
+			src:  remote("remote.dat"),
+			out:  path.Join(baseDir, "remote-copy.dat"),
+		},
+		{
+			name:    "resume-local",
+			dst:     path.Join(tmp, "resumed.dat"),
+			src:     remote("remote.dat"),
+			out:     path.Join(tmp, "resumed.dat"),
+			partial: 3*1024*1024 + 1,
+		},
+		{
+			name:    "resume-remote",
+			dst:     remote("resumed.dat"),
+			src:     path.Join(tmp, "src.dat"),
+			out:     path.Join(baseDir, "resumed.dat"),
+			partial: 1024,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []xrootd.CopyOption
+			if tc.partial > 0 {
+				err := ioutil.WriteFile(tc.out, want[:tc.partial], 0644)
+				if err != nil {
+					t.Fatalf("could not create partial file: %v", err)
+				}
+				opts = append(opts, xrootd.WithCopyResume())
+			}
+
+			var calls int
+			opts = append(opts, xrootd.WithCopyProgress(func(n, size int64) {
+				calls++
+				if size != int64(len(want)) {
+					t.Errorf("invalid total size: got=%d, want=%d", size, len(want))
+				}
+			}))
+
+			n, err := cli.Copy(context.Background(), tc.dst, tc.src, opts...)
+			if err != nil {
+				t.Fatalf("could not copy %q to %q: %+v", tc.src, tc.dst, err)
+			}
+
+			if got, want := n, int64(len(want)-tc.partial); got != want {
+				t.Fatalf("invalid number of bytes transferred: got=%d, want=%d", got, want)
+			}
+			if calls == 0 {
+				t.Fatalf("progress callback not called")
+			}
+
+			got, err := ioutil.ReadFile(tc.out)
+			if err != nil {
+				t.Fatalf("could not read copied file: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("copied file content differs")
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		dst  string
+		src  string
+	}{
+		{
+			name: "local-to-local",
+			dst:  path.Join(tmp, "dst.dat"),
+			src:  path.Join(tmp, "src.dat"),
+		},
+		{
+			name: "same-remote-file",
+			dst:  remote("remote.dat"),
+			src:  "root://" + addr + "///remote.dat",
+		},
+		{
+			name: "other-server",
+			dst:  path.Join(tmp, "other.dat"),
+			src:  "root://other.example.com:1094//remote.dat",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := cli.Copy(context.Background(), tc.dst, tc.src)
+			if err == nil {
+				t.Fatalf("expected an error when copying %q to %q", tc.src, tc.dst)
+			}
+		})
+	}
+
+	// the source file was left untouched.
+	got, err := ioutil.ReadFile(path.Join(baseDir, "remote.dat"))
+	if err != nil {
+		t.Fatalf("could not read source file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("source file content differs")
+	}
+}
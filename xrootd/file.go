@@ -5,10 +5,14 @@
 package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"io"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
 	"go-hep.org/x/hep/xrootd/xrdproto/sync"
 	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
@@ -17,6 +21,21 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/xrdclose"
 )
 
+// maxWriteSize is the maximum number of bytes sent with a single write request.
+// Larger writes are split into several requests.
+const maxWriteSize = 2 * 1024 * 1024
+
+// maxReadSize is the maximum number of bytes requested with a single read request.
+// Larger reads are split into several requests.
+const maxReadSize = 2 * 1024 * 1024
+
+// readAheadSize is the size of the read-ahead buffer of sequential file readers.
+const readAheadSize = 1024 * 1024
+
+// maxReadVSegments is the maximum number of segments sent with a single readv request.
+// Larger vectored reads are split into several requests.
+const maxReadVSegments = 1024
+
 // File implements access to a content and meta information of file over XRootD.
 type file struct {
 	fs          *fileSystem
@@ -43,23 +62,25 @@ func (f file) Handle() xrdfs.FileHandle {
 }
 
 // Close closes the file.
-func (f file) Close(ctx context.Context) error {
+func (f *file) Close(ctx context.Context) error {
 	newSessionID, err := f.fs.c.sendSession(ctx, f.sessionID, nil, &xrdclose.Request{Handle: f.handle})
 	if err != nil {
 		return err
 	}
 	f.sessionID = newSessionID
+	f.fs.c.removeFile(f)
 	return nil
 }
 
 // CloseVerify closes the file and checks whether the file has the provided size.
 // A zero size suppresses the verification.
-func (f file) CloseVerify(ctx context.Context, size int64) error {
+func (f *file) CloseVerify(ctx context.Context, size int64) error {
 	newSessionID, err := f.fs.c.sendSession(ctx, f.sessionID, nil, &xrdclose.Request{Handle: f.handle, Size: size})
 	if err != nil {
 		return err
 	}
 	f.sessionID = newSessionID
+	f.fs.c.removeFile(f)
 	return nil
 }
 
@@ -74,7 +95,28 @@ func (f file) Sync(ctx context.Context) error {
 }
 
 // ReadAtContext reads len(p) bytes into p starting at offset off.
+// Large buffers are read with sequential requests of at most maxReadSize bytes.
+// A short read, without error, is returned if the end of the file is reached.
 func (f file) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error) {
+	for n < len(p) {
+		chunk := p[n:]
+		if len(chunk) > maxReadSize {
+			chunk = chunk[:maxReadSize]
+		}
+		nn, err := f.read(ctx, chunk, off+int64(n))
+		n += nn
+		if err != nil {
+			return n, err
+		}
+		if nn < len(chunk) {
+			break
+		}
+	}
+	return n, nil
+}
+
+// read sends a single read request for len(p) bytes starting at offset off.
+func (f file) read(ctx context.Context, p []byte, off int64) (int, error) {
 	resp := read.Response{Data: p}
 	req := &read.Request{Handle: f.handle, Offset: off, Length: int32(len(p))}
 	newSessionID, err := f.fs.c.sendSession(ctx, f.sessionID, &resp, req)
@@ -86,17 +128,83 @@ func (f file) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, er
 }
 
 // ReadAt reads len(p) bytes into p starting at offset off.
+// ReadAt implements io.ReaderAt: short reads are retried until p is full,
+// and io.EOF is returned if the end of the file is reached before.
 func (f file) ReadAt(p []byte, off int64) (n int, err error) {
-	return f.ReadAtContext(context.Background(), p, off)
+	ctx := context.Background()
+	for n < len(p) {
+		nn, err := f.ReadAtContext(ctx, p[n:], off+int64(n))
+		n += nn
+		if err != nil {
+			return n, err
+		}
+		if nn == 0 {
+			return n, io.EOF
+		}
+	}
+	return n, nil
 }
 
-// WriteAtContext writes len(p) bytes from p to the file at offset off.
-func (f file) WriteAtContext(ctx context.Context, p []byte, off int64) error {
-	newSessionID, err := f.fs.c.sendSession(ctx, f.sessionID, nil, &write.Request{Handle: f.handle, Offset: off, Data: p})
+// ReadV reads the provided segments using vectored reads.
+// Segments are sent in batches of at most maxReadVSegments segments.
+// The Data field of each segment is resized to the number of bytes read.
+func (f file) ReadV(ctx context.Context, segs []xrdfs.ReadVSegment) error {
+	for len(segs) > 0 {
+		n := len(segs)
+		if n > maxReadVSegments {
+			n = maxReadVSegments
+		}
+		err := f.readv(ctx, segs[:n])
+		if err != nil {
+			return err
+		}
+		segs = segs[n:]
+	}
+	return nil
+}
+
+func (f file) readv(ctx context.Context, segs []xrdfs.ReadVSegment) error {
+	req := &readv.Request{Segments: make([]readv.Segment, len(segs))}
+	for i, seg := range segs {
+		req.Segments[i] = readv.Segment{Handle: f.handle, Length: int32(len(seg.Data)), Offset: seg.Offset}
+	}
+
+	var resp readv.Response
+	newSessionID, err := f.fs.c.sendSession(ctx, f.sessionID, &resp, req)
 	if err != nil {
 		return err
 	}
 	f.sessionID = newSessionID
+
+	if len(resp.Segments) != len(segs) {
+		return fmt.Errorf("xrootd: readv returned %d segments, want %d", len(resp.Segments), len(segs))
+	}
+	for i, seg := range resp.Segments {
+		if seg.Offset != segs[i].Offset || int(seg.Length) > len(segs[i].Data) {
+			return fmt.Errorf("xrootd: readv returned an unexpected segment (offset=%d, length=%d)", seg.Offset, seg.Length)
+		}
+		n := copy(segs[i].Data, resp.Data[i])
+		segs[i].Data = segs[i].Data[:n]
+	}
+	return nil
+}
+
+// WriteAtContext writes len(p) bytes from p to the file at offset off.
+// Large buffers are sent in chunks of at most maxWriteSize bytes.
+func (f file) WriteAtContext(ctx context.Context, p []byte, off int64) error {
+	for len(p) > 0 {
+		n := len(p)
+		if n > maxWriteSize {
+			n = maxWriteSize
+		}
+		newSessionID, err := f.fs.c.sendSession(ctx, f.sessionID, nil, &write.Request{Handle: f.handle, Offset: off, Data: p[:n]})
+		if err != nil {
+			return err
+		}
+		f.sessionID = newSessionID
+		p = p[n:]
+		off += int64(n)
+	}
 	return nil
 }
 
@@ -159,6 +267,49 @@ func (f file) VerifyWriteAt(ctx context.Context, p []byte, off int64) error {
 	return nil
 }
 
+// fileReader reads a file sequentially, from start to finish,
+// through a read-ahead buffer.
+type fileReader struct {
+	ctx context.Context
+	f   xrdfs.File
+	off int64 // offset of the next read request
+	buf *bufio.Reader
+}
+
+func newFileReader(ctx context.Context, f xrdfs.File) *fileReader {
+	r := &fileReader{ctx: ctx, f: f}
+	r.buf = bufio.NewReaderSize(readerFunc(r.read), readAheadSize)
+	return r
+}
+
+// Read implements io.Reader.
+func (r *fileReader) Read(p []byte) (int, error) {
+	return r.buf.Read(p)
+}
+
+// Close closes the file, releasing its handle on the server.
+func (r *fileReader) Close() error {
+	return r.f.Close(r.ctx)
+}
+
+func (r *fileReader) read(p []byte) (int, error) {
+	n, err := r.f.ReadAtContext(r.ctx, p, r.off)
+	r.off += int64(n)
+	if err != nil {
+		return n, err
+	}
+	if n == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// readerFunc adapts a function to the io.Reader interface.
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
+
 var (
-	_ xrdfs.File = (*file)(nil)
+	_ xrdfs.File    = (*file)(nil)
+	_ io.ReadCloser = (*fileReader)(nil)
 )
@@ -5,9 +5,11 @@
 package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"hash/crc32"
+	"io"
 	"net"
 	"reflect"
 	"testing"
@@ -15,6 +17,7 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
 	"go-hep.org/x/hep/xrootd/xrdproto/sync"
 	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
@@ -158,6 +161,33 @@ func TestFile_ReadAt_Mock(t *testing.T) {
 			cancel()
 			t.Fatalf("could not write response: %v", err)
 		}
+
+		// short read: the client asks for the remaining bytes.
+		data, err = xrdproto.ReadRequest(conn)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not read request: %v", err)
+		}
+
+		gotRequest = read.Request{}
+		gotHeader, err = unmarshalRequest(data, &gotRequest)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not unmarshal request: %v", err)
+		}
+
+		gotRequest.OptionalArgs = nil
+		wantRequest = read.Request{Handle: handle, Offset: 1 + int64(len(want)), Length: askLength - int32(len(want))}
+		if !reflect.DeepEqual(gotRequest, wantRequest) {
+			cancel()
+			t.Fatalf("request info does not match:\ngot = %v\nwant = %v", gotRequest, wantRequest)
+		}
+
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, read.Response{})
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
 	}
 
 	clientFunc := func(cancel func(), client *Client) {
@@ -165,8 +195,8 @@ func TestFile_ReadAt_Mock(t *testing.T) {
 		got := make([]uint8, askLength)
 
 		n, err := file.ReadAt(got, 1)
-		if err != nil {
-			t.Fatalf("invalid read call: %v", err)
+		if err != io.EOF {
+			t.Fatalf("invalid read call: got=%v, want=%v", err, io.EOF)
 		}
 		if n != len(want) {
 			t.Fatalf("read count does not match:\ngot = %v\nwant = %v", n, len(want))
@@ -180,6 +210,157 @@ func TestFile_ReadAt_Mock(t *testing.T) {
 	testClientWithMockServer(serverFunc, clientFunc)
 }
 
+func TestFile_ReadAtChunked_Mock(t *testing.T) {
+	t.Parallel()
+
+	handle := xrdfs.FileHandle{1, 2, 3, 4}
+	// the file ends 10 bytes into the third chunk.
+	want := make([]byte, 2*maxReadSize+10)
+	for i := range want {
+		want[i] = byte(i)
+	}
+	askLength := 2*maxReadSize + 42
+
+	wantRequests := []read.Request{
+		{Handle: handle, Offset: 1, Length: maxReadSize},
+		{Handle: handle, Offset: 1 + maxReadSize, Length: maxReadSize},
+		{Handle: handle, Offset: 1 + 2*maxReadSize, Length: 42},
+		// short read: the client asks for the remaining bytes.
+		{Handle: handle, Offset: 1 + 2*maxReadSize + 10, Length: 32},
+	}
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		for i, wantRequest := range wantRequests {
+			data, err := xrdproto.ReadRequest(conn)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not read request #%d: %v", i, err)
+			}
+
+			var gotRequest read.Request
+			gotHeader, err := unmarshalRequest(data, &gotRequest)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not unmarshal request #%d: %v", i, err)
+			}
+
+			gotRequest.OptionalArgs = nil
+			if !reflect.DeepEqual(gotRequest, wantRequest) {
+				cancel()
+				t.Fatalf("request #%d info does not match:\ngot = %v\nwant = %v", i, gotRequest, wantRequest)
+			}
+
+			beg := int(gotRequest.Offset) - 1
+			end := beg + int(gotRequest.Length)
+			if end > len(want) {
+				end = len(want)
+			}
+			err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, read.Response{Data: want[beg:end]})
+			if err != nil {
+				cancel()
+				t.Fatalf("could not write response #%d: %v", i, err)
+			}
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		file := file{fs: client.FS().(*fileSystem), handle: handle, sessionID: client.initialSessionID}
+		got := make([]uint8, askLength)
+
+		n, err := file.ReadAt(got, 1)
+		if err != io.EOF {
+			t.Fatalf("invalid read call: got=%v, want=%v", err, io.EOF)
+		}
+		if n != len(want) {
+			t.Fatalf("read count does not match:\ngot = %v\nwant = %v", n, len(want))
+		}
+
+		if !bytes.Equal(got[:n], want) {
+			t.Fatalf("read data does not match")
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
+func TestFile_ReadV_Mock(t *testing.T) {
+	t.Parallel()
+
+	handle := xrdfs.FileHandle{1, 2, 3, 4}
+	content := []byte("Hello XRootD.\n")
+	nsegs := maxReadVSegments + 2
+
+	want := make([][]byte, nsegs)
+	segs := make([]xrdfs.ReadVSegment, nsegs)
+	for i := range segs {
+		off := i % len(content)
+		segs[i] = xrdfs.ReadVSegment{Offset: int64(off), Data: make([]byte, 4)}
+		want[i] = content[off:]
+		if len(want[i]) > 4 {
+			want[i] = want[i][:4]
+		}
+	}
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		for _, n := range []int{maxReadVSegments, nsegs - maxReadVSegments} {
+			data, err := xrdproto.ReadRequest(conn)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not read request: %v", err)
+			}
+
+			var gotRequest readv.Request
+			gotHeader, err := unmarshalRequest(data, &gotRequest)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not unmarshal request: %v", err)
+			}
+
+			if got := len(gotRequest.Segments); got != n {
+				cancel()
+				t.Fatalf("invalid number of segments: got=%d, want=%d", got, n)
+			}
+
+			var resp readv.Response
+			for _, seg := range gotRequest.Segments {
+				if seg.Handle != handle {
+					cancel()
+					t.Fatalf("invalid file handle: got=%v, want=%v", seg.Handle, handle)
+				}
+				end := seg.Offset + int64(seg.Length)
+				if end > int64(len(content)) {
+					end = int64(len(content))
+				}
+				resp.Segments = append(resp.Segments, seg)
+				resp.Data = append(resp.Data, content[seg.Offset:end])
+			}
+
+			err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, resp)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not write response: %v", err)
+			}
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		file := file{fs: client.FS().(*fileSystem), handle: handle, sessionID: client.initialSessionID}
+
+		err := file.ReadV(context.Background(), segs)
+		if err != nil {
+			t.Fatalf("invalid readv call: %v", err)
+		}
+
+		for i, seg := range segs {
+			if !reflect.DeepEqual(seg.Data, want[i]) {
+				t.Fatalf("segment %d: read data does not match:\ngot = %q\nwant = %q", i, seg.Data, want[i])
+			}
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
 func TestFile_WriteAt_Mock(t *testing.T) {
 	t.Parallel()
 
@@ -229,6 +410,66 @@ func TestFile_WriteAt_Mock(t *testing.T) {
 	testClientWithMockServer(serverFunc, clientFunc)
 }
 
+func TestFile_WriteAtChunked_Mock(t *testing.T) {
+	t.Parallel()
+
+	handle := xrdfs.FileHandle{1, 2, 3, 4}
+	want := make([]byte, 2*maxWriteSize+42)
+	for i := range want {
+		want[i] = byte(i)
+	}
+
+	wantRequests := []write.Request{
+		{Handle: handle, Offset: 1, Data: want[:maxWriteSize]},
+		{Handle: handle, Offset: 1 + maxWriteSize, Data: want[maxWriteSize : 2*maxWriteSize]},
+		{Handle: handle, Offset: 1 + 2*maxWriteSize, Data: want[2*maxWriteSize:]},
+	}
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		for i, wantRequest := range wantRequests {
+			data, err := xrdproto.ReadRequest(conn)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not read request #%d: %v", i, err)
+			}
+
+			var gotRequest write.Request
+			gotHeader, err := unmarshalRequest(data, &gotRequest)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not unmarshal request #%d: %v", i, err)
+			}
+
+			if !reflect.DeepEqual(gotRequest, wantRequest) {
+				cancel()
+				t.Fatalf("request #%d info does not match:\ngot = (offset=%d, len=%d)\nwant = (offset=%d, len=%d)",
+					i, gotRequest.Offset, len(gotRequest.Data), wantRequest.Offset, len(wantRequest.Data),
+				)
+			}
+
+			err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, nil)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not write response #%d: %v", i, err)
+			}
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		file := file{fs: client.FS().(*fileSystem), handle: handle, sessionID: client.initialSessionID}
+
+		n, err := file.WriteAt(want, 1)
+		if err != nil {
+			t.Fatalf("invalid write call: %v", err)
+		}
+		if n != len(want) {
+			t.Fatalf("write count does not match:\ngot = %v\nwant = %v", n, len(want))
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
 func TestFile_Truncate_Mock(t *testing.T) {
 	t.Parallel()
 
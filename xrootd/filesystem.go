@@ -6,14 +6,21 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	stdpath "path"
+	"strings"
 
 	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/chmod"
 	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/prepare"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -41,35 +48,101 @@ func (fs *fileSystem) Dirlist(ctx context.Context, path string) ([]xrdfs.EntrySt
 	return resp.Entries, err
 }
 
+// DirlistRecursive returns the contents of a directory and of all its
+// sub-directories, together with the stat information.
+// Entries are returned as a flat list, with their full path as name.
+// The context is checked for cancellation between requests.
+func (fs *fileSystem) DirlistRecursive(ctx context.Context, path string, opts ...xrdfs.DirlistOption) ([]xrdfs.EntryStat, error) {
+	var cfg xrdfs.DirlistConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		out  []xrdfs.EntryStat
+		walk func(path string, depth int) error
+	)
+	walk = func(path string, depth int) error {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+		entries, err := fs.Dirlist(ctx, path)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			e.EntryName = stdpath.Join(path, e.EntryName)
+			out = append(out, e)
+			if !e.IsDir() || (cfg.MaxDepth > 0 && depth >= cfg.MaxDepth) {
+				continue
+			}
+			err := walk(e.EntryName, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err := walk(path, 1)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Open returns the file handle for a file together with the compression and the stat info.
+// The file is tracked by the client until it is closed, see Client.OpenFiles.
 func (fs *fileSystem) Open(ctx context.Context, path string, mode xrdfs.OpenMode, options xrdfs.OpenOptions) (xrdfs.File, error) {
 	var resp open.Response
 	server, err := fs.c.Send(ctx, &resp, open.NewRequest(path, mode, options))
 	if err != nil {
 		return nil, err
 	}
-	return &file{fs, resp.FileHandle, resp.Compression, resp.Stat, server}, nil
+	f := &file{fs, resp.FileHandle, resp.Compression, resp.Stat, server}
+	fs.c.addFile(f)
+	return f, nil
+}
+
+// OpenReader opens the named file for reading and returns a reader reading it
+// sequentially, from start to finish, through a read-ahead buffer.
+// The context is used for all the requests sent by the reader.
+// Closing the reader closes the file.
+func (fs *fileSystem) OpenReader(ctx context.Context, path string) (io.ReadCloser, error) {
+	f, err := fs.Open(ctx, path, xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead|xrdfs.OpenOptionsSequentiallyIO)
+	if err != nil {
+		return nil, err
+	}
+	return newFileReader(ctx, f), nil
 }
 
 // RemoveFile removes a file.
 func (fs *fileSystem) RemoveFile(ctx context.Context, path string) error {
 	_, err := fs.c.Send(ctx, nil, &rm.Request{Path: path})
+	fs.c.stats.invalidate(path)
 	return err
 }
 
 // Truncate changes the size of the named file.
 func (fs *fileSystem) Truncate(ctx context.Context, path string, size int64) error {
 	_, err := fs.c.Send(ctx, nil, &truncate.Request{Path: path, Size: size})
+	fs.c.stats.invalidate(path)
 	return err
 }
 
 // Stat returns the entry stat info for the given path.
+// The stat info may be served from the cache of the client, see WithStatCache.
 func (fs *fileSystem) Stat(ctx context.Context, path string) (xrdfs.EntryStat, error) {
+	if st, ok := fs.c.stats.get(path); ok {
+		return st, nil
+	}
 	var resp stat.DefaultResponse
 	_, err := fs.c.Send(ctx, &resp, &stat.Request{Path: path})
 	if err != nil {
 		return xrdfs.EntryStat{}, err
 	}
+	fs.c.stats.put(path, resp.EntryStat)
 	return resp.EntryStat, nil
 }
 
@@ -104,6 +177,7 @@ func (fs *fileSystem) MkdirAll(ctx context.Context, path string, perm xrdfs.Open
 // The directory to be removed must be empty.
 func (fs *fileSystem) RemoveDir(ctx context.Context, path string) error {
 	_, err := fs.c.Send(ctx, nil, &rmdir.Request{Path: path})
+	fs.c.stats.invalidate(path)
 	return err
 }
 
@@ -137,12 +211,14 @@ func (fs *fileSystem) RemoveAll(ctx context.Context, path string) error {
 // Rename renames (moves) oldpath to newpath.
 func (fs *fileSystem) Rename(ctx context.Context, oldpath, newpath string) error {
 	_, err := fs.c.Send(ctx, nil, &mv.Request{OldPath: oldpath, NewPath: newpath})
+	fs.c.stats.invalidate(oldpath, newpath)
 	return err
 }
 
 // Chmod changes the permissions of the named file to perm.
 func (fs *fileSystem) Chmod(ctx context.Context, path string, perm xrdfs.OpenMode) error {
 	_, err := fs.c.Send(ctx, nil, &chmod.Request{Path: path, Mode: perm})
+	fs.c.stats.invalidate(path)
 	return err
 }
 
@@ -157,6 +233,114 @@ func (fs *fileSystem) Statx(ctx context.Context, paths []string) ([]xrdfs.StatFl
 	return resp.StatFlags, nil
 }
 
+// Checksum returns the name of the checksum algorithm and the checksum
+// of the named file, as computed by the server.
+// Checksum returns an error wrapping xrdfs.ErrChecksumUnsupported if the
+// server does not support checksums.
+func (fs *fileSystem) Checksum(ctx context.Context, path string) (algo, value string, err error) {
+	var resp query.Response
+	_, err = fs.c.Send(ctx, &resp, &query.Request{Query: query.Checksum, Args: []byte(path)})
+	if err != nil {
+		var serr xrdproto.ServerError
+		if errors.As(err, &serr) && serr.Code == xrdproto.Unsupported {
+			return "", "", fmt.Errorf("%w: %s", xrdfs.ErrChecksumUnsupported, serr.Message)
+		}
+		return "", "", err
+	}
+
+	toks := strings.Fields(strings.TrimRight(string(resp.Data), "\x00"))
+	if len(toks) != 2 {
+		return "", "", fmt.Errorf("xrootd: invalid checksum response %q", resp.Data)
+	}
+	return toks[0], toks[1], nil
+}
+
+// Prepare asks the server to prepare the named files for access,
+// e.g. by staging them from tape to disk.
+// Prepare returns the identifier of the prepare request.
+func (fs *fileSystem) Prepare(ctx context.Context, paths []string, opts xrdfs.PrepareOptions) (string, error) {
+	var resp prepare.Response
+	_, err := fs.c.Send(ctx, &resp, &prepare.Request{Options: byte(opts), Paths: paths})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimRight(string(resp.Data), "\x00")), nil
+}
+
+// PrepareStatus returns the status of the prepare request with the provided identifier,
+// as reported by the server.
+func (fs *fileSystem) PrepareStatus(ctx context.Context, id string) (string, error) {
+	var resp query.Response
+	_, err := fs.c.Send(ctx, &resp, &query.Request{Query: query.Prepare, Args: []byte(id)})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(resp.Data), "\x00"), nil
+}
+
+// Query sends a query request of the given kind to the server, with the
+// provided arguments, and returns the raw response of the server.
+func (fs *fileSystem) Query(ctx context.Context, code xrdfs.QueryCode, args []byte) ([]byte, error) {
+	var resp query.Response
+	_, err := fs.c.Send(ctx, &resp, &query.Request{Query: uint16(code), Args: args})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Config returns the values of the named server configuration items,
+// such as "readv_iov_max", "version" or "sitename".
+// Items unknown to the server are not present in the returned map.
+func (fs *fileSystem) Config(ctx context.Context, names ...string) (map[string]string, error) {
+	if len(names) == 0 {
+		return map[string]string{}, nil
+	}
+
+	data, err := fs.Query(ctx, xrdfs.QueryConfig, []byte(strings.Join(names, " ")))
+	if err != nil {
+		return nil, err
+	}
+
+	vals := strings.Split(strings.TrimRight(string(data), "\x00\n"), "\n")
+	if len(vals) != len(names) {
+		return nil, fmt.Errorf("xrootd: invalid config response %q (got=%d values, want=%d)", data, len(vals), len(names))
+	}
+
+	cfg := make(map[string]string, len(names))
+	for i, name := range names {
+		// the server sends back the name of the item when it is unknown.
+		if vals[i] == name {
+			continue
+		}
+		cfg[name] = vals[i]
+	}
+	return cfg, nil
+}
+
+// Space returns the information about the named logical space
+// (space token), such as "oss.space", "oss.free" or "oss.used", as
+// reported by the server.
+func (fs *fileSystem) Space(ctx context.Context, token string) (map[string]string, error) {
+	data, err := fs.Query(ctx, xrdfs.QuerySpace, []byte(token))
+	if err != nil {
+		return nil, err
+	}
+
+	info := make(map[string]string)
+	for _, kv := range strings.Split(strings.TrimSpace(strings.TrimRight(string(data), "\x00")), "&") {
+		if kv == "" {
+			continue
+		}
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("xrootd: invalid space response %q", data)
+		}
+		info[kv[:i]] = kv[i+1:]
+	}
+	return info, nil
+}
+
 var (
 	_ xrdfs.FileSystem = (*fileSystem)(nil)
 )
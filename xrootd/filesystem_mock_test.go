@@ -6,6 +6,8 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"reflect"
 	"testing"
@@ -17,6 +19,8 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/mv"
 	"go-hep.org/x/hep/xrootd/xrdproto/open"
+	"go-hep.org/x/hep/xrootd/xrdproto/prepare"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
 	"go-hep.org/x/hep/xrootd/xrdproto/rm"
 	"go-hep.org/x/hep/xrootd/xrdproto/rmdir"
 	"go-hep.org/x/hep/xrootd/xrdproto/stat"
@@ -706,3 +710,253 @@ func TestFileSystem_Statx_Mock(t *testing.T) {
 
 	testClientWithMockServer(serverFunc, clientFunc)
 }
+
+func TestFileSystem_Checksum_Mock(t *testing.T) {
+	t.Parallel()
+
+	path := "/tmp/test"
+	wantRequest := query.Request{Query: query.Checksum, Args: []byte(path)}
+
+	for _, tc := range []struct {
+		name   string
+		status xrdproto.ResponseStatus
+		resp   xrdproto.Marshaler
+		algo   string
+		value  string
+		err    error
+	}{
+		{
+			name:   "adler32",
+			status: xrdproto.Ok,
+			resp:   query.Response{Data: []byte("adler32 0a1b2c3d\x00")},
+			algo:   "adler32",
+			value:  "0a1b2c3d",
+		},
+		{
+			name:   "unsupported",
+			status: xrdproto.Error,
+			resp:   xrdproto.ServerError{Code: xrdproto.Unsupported, Message: "checksums not supported"},
+			err:    xrdfs.ErrChecksumUnsupported,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			serverFunc := func(cancel func(), conn net.Conn) {
+				data, err := xrdproto.ReadRequest(conn)
+				if err != nil {
+					cancel()
+					t.Fatalf("could not read request: %v", err)
+				}
+
+				var gotRequest query.Request
+				gotHeader, err := unmarshalRequest(data, &gotRequest)
+				if err != nil {
+					cancel()
+					t.Fatalf("could not unmarshal request: %v", err)
+				}
+
+				if !reflect.DeepEqual(gotRequest, wantRequest) {
+					cancel()
+					t.Fatalf("request info does not match:\ngot = %v\nwant = %v", gotRequest, wantRequest)
+				}
+
+				err = xrdproto.WriteResponse(conn, gotHeader.StreamID, tc.status, tc.resp)
+				if err != nil {
+					cancel()
+					t.Fatalf("could not write response: %v", err)
+				}
+			}
+
+			clientFunc := func(cancel func(), client *Client) {
+				algo, value, err := client.FS().Checksum(context.Background(), path)
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid checksum error: got=%v, want=%v", err, tc.err)
+				}
+				if algo != tc.algo || value != tc.value {
+					t.Fatalf("checksum does not match:\ngot = %s:%s\nwant = %s:%s", algo, value, tc.algo, tc.value)
+				}
+			}
+
+			testClientWithMockServer(serverFunc, clientFunc)
+		})
+	}
+}
+
+func TestFileSystem_Prepare_Mock(t *testing.T) {
+	t.Parallel()
+
+	paths := []string{"/tmp/test1", "/tmp/test2"}
+	wantID := "0123456789abcdef"
+	wantStatus := "staged"
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		data, err := xrdproto.ReadRequest(conn)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not read request: %v", err)
+		}
+
+		var gotRequest prepare.Request
+		gotHeader, err := unmarshalRequest(data, &gotRequest)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not unmarshal request: %v", err)
+		}
+
+		wantRequest := prepare.Request{Options: prepare.Stage | prepare.Colocate, Paths: paths}
+		if !reflect.DeepEqual(gotRequest, wantRequest) {
+			cancel()
+			t.Fatalf("request info does not match:\ngot = %v\nwant = %v", gotRequest, wantRequest)
+		}
+
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, prepare.Response{Data: []byte(wantID + "\n\x00")})
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+
+		data, err = xrdproto.ReadRequest(conn)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not read request: %v", err)
+		}
+
+		var gotQuery query.Request
+		gotHeader, err = unmarshalRequest(data, &gotQuery)
+		if err != nil {
+			cancel()
+			t.Fatalf("could not unmarshal request: %v", err)
+		}
+
+		wantQuery := query.Request{Query: query.Prepare, Args: []byte(wantID)}
+		if !reflect.DeepEqual(gotQuery, wantQuery) {
+			cancel()
+			t.Fatalf("request info does not match:\ngot = %v\nwant = %v", gotQuery, wantQuery)
+		}
+
+		err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, query.Response{Data: []byte(wantStatus + "\x00")})
+		if err != nil {
+			cancel()
+			t.Fatalf("could not write response: %v", err)
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		fs := client.FS()
+		id, err := fs.Prepare(context.Background(), paths, xrdfs.PrepareStage|xrdfs.PrepareColocate)
+		if err != nil {
+			t.Fatalf("invalid prepare call: %v", err)
+		}
+		if id != wantID {
+			t.Fatalf("invalid prepare request id: got=%q, want=%q", id, wantID)
+		}
+
+		status, err := fs.PrepareStatus(context.Background(), id)
+		if err != nil {
+			t.Fatalf("invalid prepare status call: %v", err)
+		}
+		if status != wantStatus {
+			t.Fatalf("invalid prepare status: got=%q, want=%q", status, wantStatus)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
+func TestFileSystem_Query_Mock(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		req  query.Request
+		resp []byte
+	}{
+		{
+			req:  query.Request{Query: query.Stats, Args: []byte("a")},
+			resp: []byte("<statistics/>\x00"),
+		},
+		{
+			req:  query.Request{Query: query.Config, Args: []byte("readv_iov_max version unknown")},
+			resp: []byte("1024\nv4.12.1\nunknown\n\x00"),
+		},
+		{
+			req:  query.Request{Query: query.Space, Args: []byte("public")},
+			resp: []byte("oss.cgroup=public&oss.space=100&oss.free=40&oss.maxf=20&oss.used=60&oss.quota=-1\x00"),
+		},
+	} {
+		tc := tc
+		t.Run(fmt.Sprintf("query-%d", tc.req.Query), func(t *testing.T) {
+			serverFunc := func(cancel func(), conn net.Conn) {
+				data, err := xrdproto.ReadRequest(conn)
+				if err != nil {
+					cancel()
+					t.Fatalf("could not read request: %v", err)
+				}
+
+				var gotRequest query.Request
+				gotHeader, err := unmarshalRequest(data, &gotRequest)
+				if err != nil {
+					cancel()
+					t.Fatalf("could not unmarshal request: %v", err)
+				}
+
+				if !reflect.DeepEqual(gotRequest, tc.req) {
+					cancel()
+					t.Fatalf("request info does not match:\ngot = %v\nwant = %v", gotRequest, tc.req)
+				}
+
+				err = xrdproto.WriteResponse(conn, gotHeader.StreamID, xrdproto.Ok, query.Response{Data: tc.resp})
+				if err != nil {
+					cancel()
+					t.Fatalf("could not write response: %v", err)
+				}
+			}
+
+			clientFunc := func(cancel func(), client *Client) {
+				ctx := context.Background()
+				fs := client.FS()
+				switch tc.req.Query {
+				case query.Stats:
+					got, err := fs.Query(ctx, xrdfs.QueryStats, []byte("a"))
+					if err != nil {
+						t.Fatalf("invalid query call: %v", err)
+					}
+					if !reflect.DeepEqual(got, tc.resp) {
+						t.Fatalf("invalid query response:\ngot = %q\nwant = %q", got, tc.resp)
+					}
+
+				case query.Config:
+					got, err := fs.Config(ctx, "readv_iov_max", "version", "unknown")
+					if err != nil {
+						t.Fatalf("invalid config call: %v", err)
+					}
+					want := map[string]string{
+						"readv_iov_max": "1024",
+						"version":       "v4.12.1",
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Fatalf("invalid config:\ngot = %v\nwant = %v", got, want)
+					}
+
+				case query.Space:
+					got, err := fs.Space(ctx, "public")
+					if err != nil {
+						t.Fatalf("invalid space call: %v", err)
+					}
+					want := map[string]string{
+						"oss.cgroup": "public",
+						"oss.space":  "100",
+						"oss.free":   "40",
+						"oss.maxf":   "20",
+						"oss.used":   "60",
+						"oss.quota":  "-1",
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Fatalf("invalid space info:\ngot = %v\nwant = %v", got, want)
+					}
+				}
+			}
+
+			testClientWithMockServer(serverFunc, clientFunc)
+		})
+	}
+}
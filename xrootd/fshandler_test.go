@@ -7,12 +7,14 @@ package xrootd_test // import "go-hep.org/x/hep/xrootd"
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"net"
 	"os"
 	"path"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 
@@ -20,6 +22,7 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdfs"
 	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/ping"
+	"go-hep.org/x/hep/xrootd/xrdproto/stat"
 )
 
 func getTCPAddr() (string, error) {
@@ -139,6 +142,98 @@ func TestHandler_Dirlist_WhenPathIsInvalid(t *testing.T) {
 	}
 }
 
+func TestHandler_DirlistRecursive(t *testing.T) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer srv.Shutdown(context.Background())
+
+	for _, name := range []string{
+		"file1.txt",
+		"dir1/file2.txt",
+		"dir1/dir2/file3.txt",
+		"dir1/dir2/dir3/file4.txt",
+	} {
+		fname := path.Join(baseDir, name)
+		err = os.MkdirAll(path.Dir(fname), 0755)
+		if err != nil {
+			t.Fatalf("could not create test dir: %v", err)
+		}
+		err = ioutil.WriteFile(fname, nil, 0644)
+		if err != nil {
+			t.Fatalf("could not create test file: %v", err)
+		}
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, tc := range []struct {
+		name  string
+		ctx   context.Context
+		depth int
+		want  []string
+		err   error
+	}{
+		{
+			name: "all",
+			ctx:  context.Background(),
+			want: []string{
+				"/dir1", "/dir1/dir2", "/dir1/dir2/dir3",
+				"/dir1/dir2/dir3/file4.txt", "/dir1/dir2/file3.txt",
+				"/dir1/file2.txt", "/file1.txt",
+			},
+		},
+		{
+			name:  "depth=1",
+			ctx:   context.Background(),
+			depth: 1,
+			want:  []string{"/dir1", "/file1.txt"},
+		},
+		{
+			name:  "depth=2",
+			ctx:   context.Background(),
+			depth: 2,
+			want:  []string{"/dir1", "/dir1/dir2", "/dir1/file2.txt", "/file1.txt"},
+		},
+		{
+			name: "canceled",
+			ctx:  canceled,
+			err:  context.Canceled,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := cli.FS().DirlistRecursive(tc.ctx, "/", xrdfs.WithMaxDepth(tc.depth))
+			if err != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Name())
+			}
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid entries:\ngot = %v\nwant= %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestHandler_Dirlist_With1000Requests(t *testing.T) {
 	srv, addr, baseDir, err := createServer(func(err error) {
 		t.Error(err)
@@ -206,6 +301,109 @@ func BenchmarkHandler_Dirlist(b *testing.B) {
 	}
 }
 
+func TestHandler_SendAsync(t *testing.T) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer srv.Shutdown(context.Background())
+
+	const n = 100
+	for i := 0; i < n; i++ {
+		err = ioutil.WriteFile(path.Join(baseDir, fmt.Sprintf("file%d.txt", i)), make([]byte, i), 0777)
+		if err != nil {
+			t.Fatalf("could not create test file: %v", err)
+		}
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	var (
+		resps = make([]stat.DefaultResponse, n)
+		chans = make([]<-chan xrootd.AsyncResponse, n)
+	)
+	for i := range chans {
+		chans[i], err = cli.SendAsync(context.Background(), &resps[i], &stat.Request{Path: fmt.Sprintf("/file%d.txt", i)})
+		if err != nil {
+			t.Fatalf("could not send request %d: %v", i, err)
+		}
+	}
+
+	for i, ch := range chans {
+		resp := <-ch
+		if resp.Err != nil {
+			t.Fatalf("request %d failed: %v", i, resp.Err)
+		}
+		if got, want := resps[i].EntryStat.EntrySize, int64(i); got != want {
+			t.Fatalf("invalid size for file %d: got=%d, want=%d", i, got, want)
+		}
+	}
+}
+
+func BenchmarkHandler_Stat(b *testing.B) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		b.Error(err)
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer srv.Shutdown(context.Background())
+
+	file := path.Join(baseDir, "file1.txt")
+	err = ioutil.WriteFile(file, nil, 0777)
+	if err != nil {
+		b.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		b.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	const n = 100
+
+	b.Run("Serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < n; j++ {
+				var resp stat.DefaultResponse
+				_, err := cli.Send(context.Background(), &resp, &stat.Request{Path: "/file1.txt"})
+				if err != nil {
+					b.Fatalf("could not stat: %v", err)
+				}
+			}
+		}
+	})
+
+	b.Run("Async", func(b *testing.B) {
+		var (
+			resps = make([]stat.DefaultResponse, n)
+			chans = make([]<-chan xrootd.AsyncResponse, n)
+		)
+		for i := 0; i < b.N; i++ {
+			for j := range chans {
+				chans[j], err = cli.SendAsync(context.Background(), &resps[j], &stat.Request{Path: "/file1.txt"})
+				if err != nil {
+					b.Fatalf("could not send stat: %v", err)
+				}
+			}
+			for _, ch := range chans {
+				if resp := <-ch; resp.Err != nil {
+					b.Fatalf("could not stat: %v", resp.Err)
+				}
+			}
+		}
+	})
+}
+
 func TestHandler_Open(t *testing.T) {
 	for _, tc := range []struct {
 		testName      string
@@ -408,9 +606,11 @@ func TestHandler_Read(t *testing.T) {
 			defer gotFile.Close(context.Background())
 
 			got := make([]byte, tc.length)
-			_, err = gotFile.ReadAt(got, tc.offset)
-
-			if err != nil {
+			n, err := gotFile.ReadAt(got, tc.offset)
+			switch {
+			case err == io.EOF && n < len(got):
+				// short read at the end of the file.
+			case err != nil:
 				t.Fatalf("could not call ReadAt: %v", err)
 			}
 
@@ -421,6 +621,62 @@ func TestHandler_Read(t *testing.T) {
 	}
 }
 
+func TestHandler_OpenReader(t *testing.T) {
+	srv, addr, baseDir, err := createServer(func(err error) {
+		t.Error(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	defer srv.Shutdown(context.Background())
+
+	want := make([]byte, 3*1024*1024+17)
+	_, err = rand.Read(want)
+	if err != nil {
+		t.Fatalf("could not prepare test data: %v", err)
+	}
+
+	err = ioutil.WriteFile(path.Join(baseDir, "file1.txt"), want, 0777)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	cli, err := createClient(addr)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	ctx := context.Background()
+	r, err := cli.FS().OpenReader(ctx, "file1.txt")
+	if err != nil {
+		t.Fatalf("could not call OpenReader: %v", err)
+	}
+
+	got, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong data: got %d bytes, want %d bytes", len(got), len(want))
+	}
+
+	err = r.Close()
+	if err != nil {
+		t.Fatalf("could not close reader: %v", err)
+	}
+	err = r.Close()
+	if err == nil {
+		t.Fatalf("expected an error closing an already closed reader")
+	}
+
+	_, err = cli.FS().OpenReader(ctx, "missing.txt")
+	if err == nil {
+		t.Fatalf("expected an error opening a missing file")
+	}
+}
+
 func TestHandler_Write(t *testing.T) {
 	bigData := make([]byte, 10*1024)
 	_, err := rand.Read(bigData)
@@ -428,6 +684,13 @@ func TestHandler_Write(t *testing.T) {
 		t.Fatalf("could not prepare test data: %v", err)
 	}
 
+	// larger than the maximum size of a single write request.
+	hugeData := make([]byte, 5*1024*1024+3)
+	_, err = rand.Read(hugeData)
+	if err != nil {
+		t.Fatalf("could not prepare test data: %v", err)
+	}
+
 	for _, tc := range []struct {
 		testName    string
 		initialData []byte
@@ -471,6 +734,13 @@ func TestHandler_Write(t *testing.T) {
 			want:     bigData,
 			n:        len(bigData),
 		},
+		{
+			testName: "With length larger than a request",
+			data:     hugeData,
+			offset:   0,
+			want:     hugeData,
+			n:        len(hugeData),
+		},
 	} {
 		t.Run(tc.testName, func(t *testing.T) {
 			srv, addr, baseDir, err := createServer(func(err error) {
@@ -38,6 +38,7 @@ func (sess *cliSession) handshake(ctx context.Context) error {
 	}
 
 	sess.protocolVersion = result.ProtocolVersion
+	sess.serverType = result.ServerType
 
 	return nil
 }
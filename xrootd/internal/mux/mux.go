@@ -48,6 +48,10 @@ import (
 	"go-hep.org/x/hep/xrootd/xrdproto"
 )
 
+// ErrClosed is the error wrapped by the errors returned when a closed Mux
+// is used, or when the Mux is closed while a response is awaited.
+var ErrClosed = errors.New("mux: closed")
+
 // ServerResponse contains slice of bytes Data representing data from
 // XRootD server response (see XRootD protocol specification) and
 // Err representing error received from server or occurred
@@ -150,7 +154,7 @@ func (m *Mux) Close() {
 	m.mu.Unlock()
 	close(m.quit)
 
-	response := ServerResponse{Err: errors.New("xrootd: close was called before response was fully received")}
+	response := ServerResponse{Err: fmt.Errorf("xrootd: close was called before response was fully received: %w", ErrClosed)}
 	for streamID := range m.dataWaiters {
 		m.SendData(streamID, response)
 		m.Unclaim(streamID)
@@ -168,7 +172,7 @@ func (m *Mux) Claim() (xrdproto.StreamID, DataRecvChan, error) {
 		m.mu.Lock()
 		if m.closed {
 			m.mu.Unlock()
-			return xrdproto.StreamID{}, nil, errors.New("mux: Claim was called on closed Mux")
+			return xrdproto.StreamID{}, nil, fmt.Errorf("mux: Claim was called on closed Mux: %w", ErrClosed)
 		}
 		if _, claimed := m.dataWaiters[streamId]; claimed { // Skip id if it was already claimed manually via ClaimWithID
 			m.mu.Unlock()
@@ -186,7 +190,7 @@ func (m *Mux) ClaimWithID(id xrdproto.StreamID) (DataRecvChan, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if m.closed {
-		return nil, errors.New("mux: ClaimWithID was called on closed Mux")
+		return nil, fmt.Errorf("mux: ClaimWithID was called on closed Mux: %w", ErrClosed)
 	}
 	ch := make(chan ServerResponse)
 
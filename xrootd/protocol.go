@@ -7,9 +7,30 @@ package xrootd // import "go-hep.org/x/hep/xrootd"
 import (
 	"context"
 
+	"go-hep.org/x/hep/xrootd/xrdproto"
 	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
 )
 
+// ServerInfo describes the server a client is connected to, as advertised
+// by the server when the connection was established.
+type ServerInfo struct {
+	ProtocolVersion int32                  // binary protocol version of the server
+	Type            xrdproto.ServerType    // type of the server, as returned by the handshake
+	Flags           protocol.Flags         // roles and attributes of the server
+	SecurityLevel   xrdproto.SecurityLevel // security level required by the server
+}
+
+// IsManager returns whether the server has the manager role,
+// i.e. whether it redirects clients to other servers.
+func (info ServerInfo) IsManager() bool { return info.Flags&protocol.IsManager != 0 }
+
+// IsServer returns whether the server has the server role,
+// i.e. whether it serves data.
+func (info ServerInfo) IsServer() bool { return info.Flags&protocol.IsServer != 0 }
+
+// IsProxy returns whether the server is a proxy server.
+func (info ServerInfo) IsProxy() bool { return info.Flags&protocol.IsProxy != 0 }
+
 // Protocol obtains the protocol version number, type of the server and security information, such as:
 // the security version, the security options, the security level, and the list of alterations
 // needed to the specified predefined security level.
@@ -0,0 +1,192 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/internal/mux"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/dirlist"
+	"go-hep.org/x/hep/xrootd/xrdproto/locate"
+	"go-hep.org/x/hep/xrootd/xrdproto/ping"
+	"go-hep.org/x/hep/xrootd/xrdproto/protocol"
+	"go-hep.org/x/hep/xrootd/xrdproto/query"
+	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
+	"go-hep.org/x/hep/xrootd/xrdproto/stat"
+	"go-hep.org/x/hep/xrootd/xrdproto/statx"
+	"go-hep.org/x/hep/xrootd/xrdproto/sync"
+	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
+	"go-hep.org/x/hep/xrootd/xrdproto/verifyw"
+	"go-hep.org/x/hep/xrootd/xrdproto/write"
+	"go-hep.org/x/hep/xrootd/xrdproto/xrdclose"
+)
+
+// RetryPolicy describes how requests failing because of a transient,
+// connection-level, error are re-sent to the server.
+// Errors reported by the server are never retried.
+//
+// The connection to the server is re-established before a request is re-sent.
+// File handles are tied to a connection: requests using a file handle
+// (read, readv, write, ...) are thus never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent.
+	// A value of zero or one disables retries.
+	MaxAttempts int
+
+	// BaseBackoff is the time to wait before the first retry.
+	// The waiting time is doubled after each retry.
+	BaseBackoff time.Duration
+
+	// MaxBackoff is the maximum time to wait between two retries.
+	// A value of zero means no limit.
+	MaxBackoff time.Duration
+
+	// RetryWrites enables retries of non-idempotent requests (mkdir, rm, mv, ...).
+	// By default, only idempotent requests (stat, dirlist, query, ...) are retried.
+	RetryWrites bool
+}
+
+// WithRetryPolicy configures how the XRootD client retries requests that
+// failed because of a transient error.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(client *Client) error {
+		client.retry = p
+		return nil
+	}
+}
+
+// backoff returns the time to wait before the n-th retry.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < n; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// retryable returns whether the request req that failed with err should be re-sent.
+func (p RetryPolicy) retryable(req xrdproto.Request, err error) bool {
+	if !isConnError(err) {
+		return false
+	}
+	if usesFileHandle(req) {
+		// the file handle is invalidated when the connection is re-established.
+		return false
+	}
+	return p.RetryWrites || isIdempotent(req)
+}
+
+// isConnError returns whether err is a connection-level error, i.e. a failure
+// of the transport to the server (a network error, a connection closed by the
+// server, a session closed after such a failure, ...).
+// Errors reported by the server, cancellations of the request and errors
+// while encoding the request or decoding the response are not connection-level
+// errors: the connection to the server is still usable.
+func isConnError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var serr xrdproto.ServerError
+	if errors.As(err, &serr) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, mux.ErrClosed) {
+		return true
+	}
+	var nerr net.Error
+	return errors.As(err, &nerr)
+}
+
+// isIdempotent returns whether req may safely be sent multiple times.
+func isIdempotent(req xrdproto.Request) bool {
+	switch req.ReqID() {
+	case stat.RequestID, statx.RequestID, dirlist.RequestID,
+		query.RequestID, locate.RequestID, ping.RequestID,
+		protocol.RequestID:
+		return true
+	}
+	return false
+}
+
+// usesFileHandle returns whether req refers to a file through a file handle.
+func usesFileHandle(req xrdproto.Request) bool {
+	switch req := req.(type) {
+	case *stat.Request:
+		return req.Path == ""
+	case *truncate.Request:
+		return req.Path == ""
+	}
+	switch req.ReqID() {
+	case read.RequestID, readv.RequestID, write.RequestID,
+		xrdclose.RequestID, sync.RequestID, verifyw.RequestID:
+		return true
+	}
+	return false
+}
+
+// sendRetry sends the request to the session identified by sessionID,
+// re-sending it according to the retry policy of the client.
+// The session is dropped if the request failed because of a connection-level
+// error, and re-established before the request is re-sent.
+func (client *Client) sendRetry(ctx context.Context, sessionID string, session *cliSession, resp xrdproto.Response, req xrdproto.Request) (*mux.Redirection, error) {
+	redirection, err := session.Send(ctx, resp, req)
+	for n := 1; isConnError(err); n++ {
+		if session != nil {
+			client.dropSession(sessionID, session)
+			session = nil
+		}
+		if n >= client.retry.MaxAttempts || !client.retry.retryable(req, err) {
+			break
+		}
+
+		select {
+		case <-time.After(client.retry.backoff(n)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// the session is not bound to the context of this request,
+		// as it may be re-used by subsequent requests.
+		session, err = client.getSession(context.Background(), sessionID, "")
+		if err != nil {
+			continue
+		}
+		redirection, err = session.Send(ctx, resp, req)
+	}
+	return redirection, err
+}
+
+// dropSession closes the provided session and removes it from the
+// sessions of the client.
+func (client *Client) dropSession(sessionID string, session *cliSession) {
+	client.removeSession(sessionID, session)
+	session.Close()
+}
+
+// removeSession removes the provided session from the sessions of the client,
+// if it is still registered under sessionID.
+func (client *Client) removeSession(sessionID string, session *cliSession) {
+	client.mu.Lock()
+	if client.sessions[sessionID] == session {
+		delete(client.sessions, sessionID)
+	}
+	client.mu.Unlock()
+}
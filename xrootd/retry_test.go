@@ -0,0 +1,301 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/internal/mux"
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+	"go-hep.org/x/hep/xrootd/xrdproto/mkdir"
+	"go-hep.org/x/hep/xrootd/xrdproto/read"
+	"go-hep.org/x/hep/xrootd/xrdproto/readv"
+	"go-hep.org/x/hep/xrootd/xrdproto/stat"
+	"go-hep.org/x/hep/xrootd/xrdproto/truncate"
+	"go-hep.org/x/hep/xrootd/xrdproto/write"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	for _, tc := range []struct {
+		n    int
+		want time.Duration
+	}{
+		{1, 10 * time.Millisecond},
+		{2, 20 * time.Millisecond},
+		{3, 40 * time.Millisecond},
+		{4, 50 * time.Millisecond},
+		{100, 50 * time.Millisecond},
+	} {
+		if got := p.backoff(tc.n); got != tc.want {
+			t.Errorf("invalid backoff for retry %d: got=%v, want=%v", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyRetryable(t *testing.T) {
+	var (
+		p      = RetryPolicy{MaxAttempts: 3, RetryWrites: true}
+		handle = xrdfs.FileHandle{1, 2, 3, 4}
+		errIO  = &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")}
+		errDec = errors.New("xrootd: invalid readv response: truncated segment header")
+	)
+	for _, tc := range []struct {
+		name string
+		req  xrdproto.Request
+		err  error
+		want bool
+	}{
+		{"stat", &stat.Request{Path: "/file"}, errIO, true},
+		{"stat-handle", &stat.Request{FileHandle: handle}, errIO, false},
+		{"stat-no-error", &stat.Request{Path: "/file"}, nil, false},
+		{"stat-server-error", &stat.Request{Path: "/file"}, xrdproto.ServerError{Code: xrdproto.NotFound}, false},
+		{"stat-decode-error", &stat.Request{Path: "/file"}, errDec, false},
+		{"stat-eof", &stat.Request{Path: "/file"}, io.ErrUnexpectedEOF, true},
+		{"stat-closed-mux", &stat.Request{Path: "/file"}, fmt.Errorf("xrootd: could not send: %w", mux.ErrClosed), true},
+		{"stat-canceled", &stat.Request{Path: "/file"}, context.Canceled, false},
+		{"stat-deadline", &stat.Request{Path: "/file"}, context.DeadlineExceeded, false},
+		{"mkdir", &mkdir.Request{Path: "/dir"}, errIO, true},
+		{"truncate", &truncate.Request{Path: "/file"}, errIO, true},
+		{"truncate-handle", &truncate.Request{Handle: handle}, errIO, false},
+		{"read", &read.Request{Handle: handle}, errIO, false},
+		{"readv", &readv.Request{Segments: []readv.Segment{{Handle: handle}}}, errIO, false},
+		{"write", &write.Request{Handle: handle}, errIO, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := p.retryable(tc.req, tc.err), tc.want; got != want {
+				t.Fatalf("invalid retryable: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xrootd-retry-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	srv := NewServer(NewFSHandler(dir), func(err error) {})
+	go srv.Serve(l)
+	defer srv.Shutdown(context.Background())
+
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}
+
+	cli, err := NewClient(context.Background(), l.Addr().String(), "gopher", WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	breakSession(t, cli)
+	_, err = cli.FS().Stat(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("stat was not retried: %+v", err)
+	}
+
+	// reads use a file handle tied to the broken connection: they are not retried.
+	err = ioutil.WriteFile(filepath.Join(dir, "file.txt"), []byte("hello"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := cli.FS().Open(context.Background(), "/file.txt", xrdfs.OpenModeOwnerRead, xrdfs.OpenOptionsOpenRead)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	breakSession(t, cli)
+	_, err = f.ReadAtContext(context.Background(), make([]byte, 5), 0)
+	if err == nil {
+		t.Fatalf("read should not have been retried")
+	}
+
+	breakSession(t, cli)
+	err = cli.FS().Mkdir(context.Background(), "/dir", xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite)
+	if err == nil {
+		t.Fatalf("mkdir should not have been retried")
+	}
+
+	// a request failing with a server error is not retried.
+	_, err = cli.FS().Stat(context.Background(), "/not-there")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	cli.retry.BaseBackoff = time.Hour
+	cli.retry.MaxBackoff = time.Hour
+	breakSession(t, cli)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = cli.FS().Stat(ctx, "/")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClientBrokenSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xrootd-broken-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	srv := NewServer(NewFSHandler(dir), func(err error) {})
+	go srv.Serve(l)
+	defer srv.Shutdown(context.Background())
+
+	// no retry policy: the broken session is replaced without re-sending any request.
+	cli, err := NewClient(context.Background(), l.Addr().String(), "gopher")
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	defer cli.Close()
+
+	sess, err := cli.getSession(context.Background(), cli.initialSessionID, "")
+	if err != nil {
+		t.Fatalf("could not get session: %+v", err)
+	}
+	sess.conn.Close()
+	waitDropped(t, cli, sess)
+
+	// subsequent requests are sent on a new session.
+	_, err = cli.FS().Stat(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("broken session was not replaced: %+v", err)
+	}
+}
+
+// rawResponse is a response body sent as is, to simulate malformed responses.
+type rawResponse []byte
+
+func (o rawResponse) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.WriteBytes(o)
+	return nil
+}
+
+func TestClientBadResponse(t *testing.T) {
+	handle := xrdfs.FileHandle{1, 2, 3, 4}
+	content := []byte("hello")
+
+	serverFunc := func(cancel func(), conn net.Conn) {
+		for _, bad := range []bool{true, false} {
+			data, err := xrdproto.ReadRequest(conn)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not read request: %v", err)
+			}
+
+			var req readv.Request
+			header, err := unmarshalRequest(data, &req)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not unmarshal request: %v", err)
+			}
+
+			var resp xrdproto.Marshaler = rawResponse{1, 2, 3}
+			if !bad {
+				resp = readv.Response{Segments: req.Segments, Data: [][]byte{content}}
+			}
+			err = xrdproto.WriteResponse(conn, header.StreamID, xrdproto.Ok, resp)
+			if err != nil {
+				cancel()
+				t.Fatalf("could not write response: %v", err)
+			}
+		}
+	}
+
+	clientFunc := func(cancel func(), client *Client) {
+		sess := client.sessions[client.initialSessionID]
+		f := file{fs: client.FS().(*fileSystem), handle: handle, sessionID: client.initialSessionID}
+		segs := []xrdfs.ReadVSegment{{Offset: 0, Data: make([]byte, len(content))}}
+
+		err := f.ReadV(context.Background(), segs)
+		if err == nil {
+			t.Fatalf("expected an error decoding a malformed response")
+		}
+
+		client.mu.RLock()
+		cur := client.sessions[client.initialSessionID]
+		client.mu.RUnlock()
+		if cur != sess {
+			t.Fatalf("session was dropped after a malformed response")
+		}
+
+		err = f.ReadV(context.Background(), segs)
+		if err != nil {
+			t.Fatalf("could not readv after a malformed response: %+v", err)
+		}
+		if got, want := segs[0].Data, content; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data: got=%q, want=%q", got, want)
+		}
+	}
+
+	testClientWithMockServer(serverFunc, clientFunc)
+}
+
+// breakSession simulates a network failure on the connection of the initial
+// session of the client, detected while a request is being sent on it.
+func breakSession(t *testing.T, cli *Client) {
+	t.Helper()
+
+	sess, err := cli.getSession(context.Background(), cli.initialSessionID, "")
+	if err != nil {
+		t.Fatalf("could not get session: %+v", err)
+	}
+	sess.conn.Close()
+	waitDropped(t, cli, sess)
+
+	// register the broken session again, as if it had been retrieved
+	// by a request just before the failure was detected.
+	cli.mu.Lock()
+	cli.sessions[cli.initialSessionID] = sess
+	cli.mu.Unlock()
+}
+
+// waitDropped waits until the broken session has been removed from the client.
+func waitDropped(t *testing.T, cli *Client, sess *cliSession) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		cli.mu.RLock()
+		cur := cli.sessions[cli.initialSessionID]
+		cli.mu.RUnlock()
+		if cur != sess {
+			return
+		}
+		select {
+		case <-timeout:
+			t.Fatalf("broken session was not removed from the client")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -42,6 +42,8 @@ type cliSession struct {
 	conn             net.Conn
 	mux              *mux.Mux
 	protocolVersion  int32
+	serverType       xrdproto.ServerType
+	info             ServerInfo
 	signRequirements signing.Requirements
 	seqID            int64
 	mu               sync.RWMutex
@@ -130,6 +132,12 @@ func newSession(ctx context.Context, address, username, token string, client *Cl
 	}
 
 	sess.signRequirements = signing.New(protocolInfo.SecurityLevel, protocolInfo.SecurityOverrides)
+	sess.info = ServerInfo{
+		ProtocolVersion: protocolInfo.BinaryProtocolVersion,
+		Type:            sess.serverType,
+		Flags:           protocolInfo.Flags,
+		SecurityLevel:   protocolInfo.SecurityLevel,
+	}
 
 	return sess, nil
 }
@@ -162,14 +170,16 @@ func (sess *cliSession) Close() error {
 }
 
 // handleReadError handles an error encountered while reading and parsing a response.
-// If the current session is equal to the initial, the error is considered critical and handleReadError panics.
+// If the current session is equal to the initial, the session is closed and all pending
+// requests fail. They may be re-issued on a new session, according to the retry policy of the client.
 // Otherwise, the current session is closed and all requests are redirected to the initial session.
+// In both cases, the session is removed from the sessions of the client, so subsequent
+// requests are sent on a new session.
 // See http://xrootd.org/doc/dev45/XRdv310.pdf, p. 11 for details.
 func (sess *cliSession) handleReadError(err error) {
 	if sess.sessionID == sess.client.initialSessionID {
-		// TODO: what should we do in case initial session is aborted?
-		// Should we try to reconnect to the server and re-issue all requests?
-		panic(err)
+		sess.closeAndRemove()
+		return
 	}
 	sess.mu.RLock()
 	resp := mux.ServerResponse{Redirection: &mux.Redirection{Addr: sess.client.initialSessionID}}
@@ -179,7 +189,16 @@ func (sess *cliSession) handleReadError(err error) {
 		_ = err
 	}
 	sess.mu.RUnlock()
+	sess.closeAndRemove()
+}
+
+// closeAndRemove closes the session and removes it from the sessions of the client.
+// The session is closed first, so that requests pending on this session (e.g. the
+// login request of a session being established while the client lock is held) fail
+// and release the client lock.
+func (sess *cliSession) closeAndRemove() {
 	sess.Close()
+	sess.client.removeSession(sess.sessionID, sess)
 }
 
 // handleWaitResponse handles a "kXR_wait" response by re-issuing the request with streamID
@@ -233,6 +252,7 @@ func (sess *cliSession) consume() {
 					return
 				}
 				sess.handleReadError(err)
+				return
 			}
 			resp.Err = nil
 			resp.Redirection = nil
@@ -250,13 +270,10 @@ func (sess *cliSession) consume() {
 			}
 
 			if err := sess.mux.SendData(header.StreamID, resp); err != nil {
-				if sess.ctx.Err() != nil {
-					// something happened to the context.
-					// ignore this error.
-					continue
-				}
-				panic(err)
-				// TODO: should we just ignore responses to unclaimed stream IDs?
+				// either something happened to the context, or the stream
+				// was released (e.g. the request timed out).
+				// drop the response.
+				continue
 			}
 
 			if header.Status != xrdproto.OkSoFar {
@@ -328,9 +345,15 @@ func (sess *cliSession) send(ctx context.Context, streamID xrdproto.StreamID, re
 
 			data = append(data, resp.Data...)
 		case <-ctx.Done():
-			if err := ctx.Err(); err != nil {
-				return nil, nil, err
-			}
+			// drain the stream while it is released, so a reply being
+			// delivered does not block the reading of other responses.
+			// a late reply to the released stream is dropped.
+			go func() {
+				for range responseChannel {
+				}
+			}()
+			sess.cleanupRequest(streamID)
+			return nil, nil, ctx.Err()
 		}
 	}
 }
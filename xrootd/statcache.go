@@ -0,0 +1,107 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	stdpath "path"
+	"strings"
+	"sync"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+// WithStatCache enables the caching of the stat information returned by
+// FileSystem.Stat, for the duration ttl.
+//
+// Cached entries are invalidated when the client truncates, renames, removes
+// or changes the permissions of the corresponding path (or of one of its
+// parent directories).
+// Changes made through open files or by other clients are only seen once
+// the cached entry has expired.
+// A zero or negative duration disables the cache, which is the default.
+func WithStatCache(ttl time.Duration) Option {
+	return func(client *Client) error {
+		client.stats = nil
+		if ttl > 0 {
+			client.stats = newStatCache(ttl)
+		}
+		return nil
+	}
+}
+
+// statCache caches the stat information of paths for a limited time.
+// A nil statCache caches nothing.
+type statCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]statEntry
+}
+
+type statEntry struct {
+	stat    xrdfs.EntryStat
+	expires time.Time
+}
+
+func newStatCache(ttl time.Duration) *statCache {
+	return &statCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]statEntry),
+	}
+}
+
+// get returns the cached stat information of path, if any and not expired.
+func (c *statCache) get(path string) (xrdfs.EntryStat, bool) {
+	if c == nil {
+		return xrdfs.EntryStat{}, false
+	}
+	path = stdpath.Clean(path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[path]
+	if !ok {
+		return xrdfs.EntryStat{}, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, path)
+		return xrdfs.EntryStat{}, false
+	}
+	return e.stat, true
+}
+
+// put caches the stat information of path.
+func (c *statCache) put(path string, st xrdfs.EntryStat) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[stdpath.Clean(path)] = statEntry{stat: st, expires: c.now().Add(c.ttl)}
+}
+
+// invalidate removes the cached stat information of the paths and
+// of everything below them.
+func (c *statCache) invalidate(paths ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, path := range paths {
+		path = stdpath.Clean(path)
+		delete(c.entries, path)
+
+		dir := strings.TrimSuffix(path, "/") + "/"
+		for name := range c.entries {
+			if strings.HasPrefix(name, dir) {
+				delete(c.entries, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xrootd // import "go-hep.org/x/hep/xrootd"
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/xrootd/xrdfs"
+)
+
+func TestStatCache(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newStatCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	for _, name := range []string{"/dir", "/dir/f1", "/dir/sub/f2", "/dir2", "/file"} {
+		c.put(name, xrdfs.EntryStat{EntryName: name})
+	}
+
+	st, ok := c.get("/dir/./f1")
+	if !ok || st.EntryName != "/dir/f1" {
+		t.Fatalf("could not get cached entry: got=%+v, ok=%v", st, ok)
+	}
+
+	c.invalidate("/dir")
+	for _, tc := range []struct {
+		name string
+		ok   bool
+	}{
+		{"/dir", false},
+		{"/dir/f1", false},
+		{"/dir/sub/f2", false},
+		{"/dir2", true},
+		{"/file", true},
+	} {
+		if _, ok := c.get(tc.name); ok != tc.ok {
+			t.Fatalf("invalid cache entry for %q: got=%v, want=%v", tc.name, ok, tc.ok)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.get("/file"); ok {
+		t.Fatalf("cache entry did not expire")
+	}
+
+	var nilc *statCache
+	nilc.put("/file", xrdfs.EntryStat{})
+	nilc.invalidate("/file")
+	if _, ok := nilc.get("/file"); ok {
+		t.Fatalf("nil cache returned an entry")
+	}
+}
+
+func TestFileSystemStatCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xrootd-stat-cache-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "file1.txt")
+	err = ioutil.WriteFile(fname, make([]byte, 10), 0644)
+	if err != nil {
+		t.Fatalf("could not create test file: %v", err)
+	}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	srv := NewServer(NewFSHandler(dir), func(err error) {})
+	go srv.Serve(l)
+	defer srv.Shutdown(context.Background())
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name   string
+		opt    Option
+		cached bool
+	}{
+		{"default", nil, false},
+		{"cache", WithStatCache(time.Hour), true},
+		{"disabled", WithStatCache(0), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.Truncate(fname, 10)
+			if err != nil {
+				t.Fatalf("could not reset test file: %v", err)
+			}
+
+			cli, err := NewClient(ctx, l.Addr().String(), "gopher", tc.opt)
+			if err != nil {
+				t.Fatalf("could not create client: %v", err)
+			}
+			defer cli.Close()
+
+			fs := cli.FS()
+			size := func(name string) int64 {
+				t.Helper()
+				st, err := fs.Stat(ctx, name)
+				if err != nil {
+					t.Fatalf("could not stat %q: %v", name, err)
+				}
+				return st.EntrySize
+			}
+
+			if got, want := size("file1.txt"), int64(10); got != want {
+				t.Fatalf("invalid size: got=%d, want=%d", got, want)
+			}
+
+			// modify the file behind the back of the client.
+			err = os.Truncate(fname, 20)
+			if err != nil {
+				t.Fatalf("could not truncate test file: %v", err)
+			}
+
+			want := int64(20)
+			if tc.cached {
+				want = 10
+			}
+			if got := size("file1.txt"); got != want {
+				t.Fatalf("invalid size after external change: got=%d, want=%d", got, want)
+			}
+
+			err = fs.Truncate(ctx, "file1.txt", 30)
+			if err != nil {
+				t.Fatalf("could not truncate file: %v", err)
+			}
+			if got, want := size("file1.txt"), int64(30); got != want {
+				t.Fatalf("invalid size after truncate: got=%d, want=%d", got, want)
+			}
+
+			err = fs.Rename(ctx, "file1.txt", "file2.txt")
+			if err != nil {
+				t.Fatalf("could not rename file: %v", err)
+			}
+			defer fs.Rename(ctx, "file2.txt", "file1.txt")
+
+			_, err = fs.Stat(ctx, "file1.txt")
+			if err == nil {
+				t.Fatalf("expected an error stating a renamed file")
+			}
+			if got, want := size("file2.txt"), int64(30); got != want {
+				t.Fatalf("invalid size after rename: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
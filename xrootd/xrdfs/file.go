@@ -39,6 +39,10 @@ type File interface {
 	// ReadAtContext reads len(p) bytes into p starting at offset off.
 	ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error)
 
+	// ReadV reads the provided segments using a single vectored read, if possible.
+	// The Data field of each segment is filled and resized to the number of bytes read.
+	ReadV(ctx context.Context, segs []ReadVSegment) error
+
 	// WriteAtContext writes len(p) bytes from p to the file at offset off.
 	WriteAtContext(ctx context.Context, p []byte, off int64) error
 
@@ -60,6 +64,13 @@ type File interface {
 	VerifyWriteAt(ctx context.Context, p []byte, off int64) error
 }
 
+// ReadVSegment describes a chunk of a file to read with a vectored read.
+// Data is read at Offset, up to len(Data) bytes.
+type ReadVSegment struct {
+	Offset int64
+	Data   []byte
+}
+
 // FileHandle is the file handle, which should be treated as opaque data.
 type FileHandle [4]byte
 
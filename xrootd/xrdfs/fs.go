@@ -7,6 +7,8 @@ package xrdfs
 
 import (
 	"context"
+	"errors"
+	"io"
 )
 
 // FileSystem implements access to a collection of named files over XRootD.
@@ -14,9 +16,19 @@ type FileSystem interface {
 	// Dirlist returns the contents of a directory together with the stat information.
 	Dirlist(ctx context.Context, path string) ([]EntryStat, error)
 
+	// DirlistRecursive returns the contents of a directory and of all its
+	// sub-directories, together with the stat information.
+	// Entries are returned as a flat list, with their full path as name.
+	DirlistRecursive(ctx context.Context, path string, opts ...DirlistOption) ([]EntryStat, error)
+
 	// Open returns the file handle for a file together with the compression and the stat info.
 	Open(ctx context.Context, path string, mode OpenMode, options OpenOptions) (File, error)
 
+	// OpenReader opens the named file for reading and returns a reader
+	// reading it sequentially, from start to finish.
+	// Closing the reader closes the file.
+	OpenReader(ctx context.Context, path string) (io.ReadCloser, error)
+
 	// RemoveFile removes the file at path.
 	RemoveFile(ctx context.Context, path string) error
 
@@ -58,6 +70,56 @@ type FileSystem interface {
 	// Statx obtains type information for one or more paths.
 	// Only a limited number of flags is meaningful such as StatIsExecutable, StatIsDir, StatIsOther, StatIsOffline.
 	Statx(ctx context.Context, paths []string) ([]StatFlags, error)
+
+	// Checksum returns the name of the checksum algorithm and the checksum
+	// of the named file, as computed by the server.
+	// Checksum returns an error wrapping ErrChecksumUnsupported if the server
+	// does not support checksums.
+	Checksum(ctx context.Context, path string) (algo, value string, err error)
+
+	// Prepare asks the server to prepare the named files for access,
+	// e.g. by staging them from tape to disk.
+	// Prepare returns the identifier of the prepare request.
+	Prepare(ctx context.Context, paths []string, opts PrepareOptions) (string, error)
+
+	// PrepareStatus returns the status of the prepare request with the provided identifier,
+	// as reported by the server.
+	PrepareStatus(ctx context.Context, id string) (string, error)
+
+	// Query sends a query request of the given kind to the server, with the
+	// provided arguments, and returns the raw response of the server.
+	Query(ctx context.Context, code QueryCode, args []byte) ([]byte, error)
+
+	// Config returns the values of the named server configuration items,
+	// such as "readv_iov_max", "version" or "sitename".
+	// Items unknown to the server are not present in the returned map.
+	Config(ctx context.Context, names ...string) (map[string]string, error)
+
+	// Space returns the information about the named logical space
+	// (space token), such as "oss.space", "oss.free" or "oss.used", as
+	// reported by the server.
+	Space(ctx context.Context, token string) (map[string]string, error)
+}
+
+// ErrChecksumUnsupported is returned when the server does not support checksums.
+var ErrChecksumUnsupported = errors.New("xrdfs: checksum not supported by server")
+
+// DirlistOption configures a recursive directory listing.
+type DirlistOption func(*DirlistConfig)
+
+// DirlistConfig holds the configuration of a recursive directory listing.
+type DirlistConfig struct {
+	// MaxDepth is the maximum number of directory levels to descend into.
+	// A MaxDepth of 1 only lists the requested directory.
+	// A zero or negative MaxDepth means no limit.
+	MaxDepth int
+}
+
+// WithMaxDepth limits the recursion depth of a directory listing.
+func WithMaxDepth(depth int) DirlistOption {
+	return func(cfg *DirlistConfig) {
+		cfg.MaxDepth = depth
+	}
 }
 
 // OpenMode is the mode in which path is to be opened.
@@ -78,6 +140,45 @@ const (
 	OpenModeOtherExecute OpenMode = 0x001 // OpenModeOtherExecute indicates that owner has execute access.
 )
 
+// PrepareOptions are the options to apply when paths are prepared.
+type PrepareOptions uint8
+
+const (
+	// PrepareCancel specifies that a previous prepare request is cancelled.
+	PrepareCancel PrepareOptions = 1 << iota
+	// PrepareNotify specifies that a message is sent when the files have been processed.
+	PrepareNotify
+	// PrepareNoErrors specifies that no notification is sent for preparation errors.
+	PrepareNoErrors
+	// PrepareStage specifies that files are staged to disk if they are not online.
+	PrepareStage
+	// PrepareWrite specifies that files are prepared with write access.
+	PrepareWrite
+	// PrepareColocate specifies that staged files are co-located, if at all possible.
+	PrepareColocate
+	// PrepareFresh specifies that the file access time is refreshed even when the location is known.
+	PrepareFresh
+	// PrepareNone specifies that paths are prepared without specific options.
+	PrepareNone PrepareOptions = 0
+)
+
+// QueryCode is the kind of information requested with a query.
+type QueryCode uint16
+
+const (
+	QueryStats          QueryCode = 1  // QueryStats queries the server statistics.
+	QueryPrepare        QueryCode = 2  // QueryPrepare queries the status of a prepare request.
+	QueryChecksum       QueryCode = 3  // QueryChecksum queries the checksum of a file.
+	QueryXAttr          QueryCode = 4  // QueryXAttr queries the extended attributes of a file.
+	QuerySpace          QueryCode = 5  // QuerySpace queries the statistics of a logical space.
+	QueryCancelChecksum QueryCode = 6  // QueryCancelChecksum cancels a checksum computation.
+	QueryConfig         QueryCode = 7  // QueryConfig queries the server configuration.
+	QueryVisa           QueryCode = 8  // QueryVisa queries the visa attributes of a file.
+	QueryOpaque1        QueryCode = 16 // QueryOpaque1 queries implementation-dependent information.
+	QueryOpaque2        QueryCode = 32 // QueryOpaque2 queries implementation-dependent information.
+	QueryOpaque3        QueryCode = 64 // QueryOpaque3 queries implementation-dependent information.
+)
+
 // OpenOptions are the options to apply when path is opened.
 type OpenOptions uint16
 
@@ -67,6 +67,37 @@ func Open(name string) (*File, error) {
 	return xf, nil
 }
 
+// Create creates the name file, where name is the absolute location of that file
+// (xrootd server address and path to the file on that server.)
+// If the file already exists, it is truncated.
+//
+// Example:
+//
+//  f, err := xrdio.Create("root://server.example.com:1094//some/path/to/file")
+func Create(name string) (*File, error) {
+	urn, err := Parse(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %q: %w", name, err)
+	}
+
+	xrd, err := xrootd.NewClient(context.Background(), urn.Addr, urn.User)
+	if err != nil {
+		return nil, fmt.Errorf("xrdio: could not connect to xrootd server %q: %w", urn.Addr, err)
+	}
+
+	fs := xrd.FS()
+	f, err := fs.Open(context.Background(), urn.Path,
+		xrdfs.OpenModeOwnerRead|xrdfs.OpenModeOwnerWrite,
+		xrdfs.OpenOptionsDelete|xrdfs.OpenOptionsOpenUpdate,
+	)
+	if err != nil {
+		xrd.Close()
+		return nil, fmt.Errorf("xrdio: could not create %q: %w", name, err)
+	}
+
+	return &File{cli: xrd, fs: fs, f: f, name: urn.Path}, nil
+}
+
 // OpenFrom opens the file name via the given filesystem handle.
 // name is the absolute path of the wanted file on the server.
 //
@@ -135,6 +166,9 @@ func (f *File) ReadAt(data []byte, offset int64) (int, error) {
 func (f *File) Write(data []byte) (int, error) {
 	n, err := f.f.WriteAt(data, f.pos)
 	f.pos += int64(n)
+	if f.pos > f.size {
+		f.size = f.pos
+	}
 	return n, err
 }
 
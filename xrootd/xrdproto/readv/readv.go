@@ -0,0 +1,140 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package readv contains the structures describing request and response for readv request.
+// See xrootd protocol specification (http://xrootd.org/doc/dev45/XRdv310.pdf, p. 104) for details.
+package readv // import "go-hep.org/x/hep/xrootd/xrdproto/readv"
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/xrootd/internal/xrdenc"
+	"go-hep.org/x/hep/xrootd/xrdfs"
+	"go-hep.org/x/hep/xrootd/xrdproto"
+)
+
+// RequestID is the id of the request, it is sent as part of message.
+// See xrootd protocol specification for details: http://xrootd.org/doc/dev45/XRdv310.pdf, 2.3 Client Request Format.
+const RequestID uint16 = 3025
+
+// segmentSize is the size of a marshaled Segment.
+const segmentSize = 16
+
+// Segment describes a chunk of data to read from a file.
+type Segment struct {
+	Handle xrdfs.FileHandle
+	Length int32
+	Offset int64
+}
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Segment) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.WriteBytes(o.Handle[:])
+	wBuffer.WriteI32(o.Length)
+	wBuffer.WriteI64(o.Offset)
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Segment) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.ReadBytes(o.Handle[:])
+	o.Length = rBuffer.ReadI32()
+	o.Offset = rBuffer.ReadI64()
+	return nil
+}
+
+// Request holds readv request parameters.
+type Request struct {
+	_        [15]uint8
+	PathID   xrdproto.PathID
+	Segments []Segment
+}
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Request) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	wBuffer.Next(15)
+	wBuffer.WriteU8(uint8(o.PathID))
+	wBuffer.WriteLen(len(o.Segments) * segmentSize)
+	for _, seg := range o.Segments {
+		err := seg.MarshalXrd(wBuffer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Request) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	rBuffer.Skip(15)
+	o.PathID = xrdproto.PathID(rBuffer.ReadU8())
+	alen := rBuffer.ReadLen()
+	if alen%segmentSize != 0 {
+		return fmt.Errorf("xrootd: invalid alen is specified: should be dividable by %d, got: %v", segmentSize, alen)
+	}
+	o.Segments = make([]Segment, alen/segmentSize)
+	for i := range o.Segments {
+		err := o.Segments[i].UnmarshalXrd(rBuffer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReqID implements xrdproto.Request.ReqID.
+func (req *Request) ReqID() uint16 { return RequestID }
+
+// ShouldSign implements xrdproto.Request.ShouldSign.
+func (req *Request) ShouldSign() bool { return false }
+
+// Response is a response for the readv request.
+// Each requested segment is returned as a segment header followed by the read data.
+type Response struct {
+	Segments []Segment
+	Data     [][]uint8
+}
+
+// MarshalXrd implements xrdproto.Marshaler.
+func (o Response) MarshalXrd(wBuffer *xrdenc.WBuffer) error {
+	if len(o.Segments) != len(o.Data) {
+		return fmt.Errorf("xrootd: readv response with %d segments and %d data chunks", len(o.Segments), len(o.Data))
+	}
+	for i, seg := range o.Segments {
+		seg.Length = int32(len(o.Data[i]))
+		err := seg.MarshalXrd(wBuffer)
+		if err != nil {
+			return err
+		}
+		wBuffer.WriteBytes(o.Data[i])
+	}
+	return nil
+}
+
+// UnmarshalXrd implements xrdproto.Unmarshaler.
+func (o *Response) UnmarshalXrd(rBuffer *xrdenc.RBuffer) error {
+	o.Segments = o.Segments[:0]
+	o.Data = o.Data[:0]
+	for rBuffer.Len() > 0 {
+		if rBuffer.Len() < segmentSize {
+			return fmt.Errorf("xrootd: invalid readv response: truncated segment header")
+		}
+		var seg Segment
+		err := seg.UnmarshalXrd(rBuffer)
+		if err != nil {
+			return err
+		}
+		if seg.Length < 0 || int(seg.Length) > rBuffer.Len() {
+			return fmt.Errorf("xrootd: invalid readv response: segment of length %d with %d bytes left", seg.Length, rBuffer.Len())
+		}
+		data := make([]uint8, seg.Length)
+		rBuffer.ReadBytes(data)
+		o.Segments = append(o.Segments, seg)
+		o.Data = append(o.Data, data)
+	}
+	return nil
+}
+
+// RespID implements xrdproto.Response.RespID.
+func (resp *Response) RespID() uint16 { return RequestID }
@@ -105,6 +105,7 @@ const (
 	IOError        ServerErrorCode = 3007 // IOError indicates that an IO error has occurred on the server side.
 	NotAuthorized  ServerErrorCode = 3010 // NotAuthorized indicates that user was not authorized for operation.
 	NotFound       ServerErrorCode = 3011 // NotFound indicates that path was not found on the remote server.
+	Unsupported    ServerErrorCode = 3013 // Unsupported indicates that the requested operation is not supported by the server.
 )
 
 func (err ServerError) Error() string {